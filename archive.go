@@ -0,0 +1,120 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// DecodeArchive reads the SARIF documents contained in the specified
+// zip or gzip-compressed tar archive, like the artifact bundles
+// produced by CI systems. The archive format is detected from its
+// contents. See [DecodeZip] for details about how the archive entries
+// are decoded.
+func DecodeArchive(name string, opts ...DecodeOption) ([]Log, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	defer f.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek archive: %w", err)
+	}
+
+	switch {
+	case bytes.Equal(magic, []byte("PK\x03\x04")):
+		fi, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("stat archive: %w", err)
+		}
+		return DecodeZip(f, fi.Size(), opts...)
+	case bytes.HasPrefix(magic, []byte("\x1f\x8b")):
+		return DecodeTarGz(f, opts...)
+	}
+	return nil, errors.New("unknown archive format")
+}
+
+// DecodeZip reads the SARIF documents contained in the provided zip
+// archive. Every regular file with the extension ".sarif" or
+// ".sarif.json" is decoded with [DecodeAll] and the resulting logs
+// are returned in the order the files appear in the archive.
+func DecodeZip(r io.ReaderAt, size int64, opts ...DecodeOption) ([]Log, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("open zip archive: %w", err)
+	}
+
+	var logs []Log
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() || !isSARIFFile(zf.Name) {
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open archive entry %v: %w", zf.Name, err)
+		}
+		ls, err := DecodeAll(rc, opts...)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("archive entry %v: %w", zf.Name, err)
+		}
+		logs = append(logs, ls...)
+	}
+	return logs, nil
+}
+
+// DecodeTarGz reads the SARIF documents contained in the provided
+// gzip-compressed tar archive. See [DecodeZip] for details about how
+// the archive entries are decoded.
+func DecodeTarGz(r io.Reader, opts ...DecodeOption) ([]Log, error) {
+	gr, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("open gzip stream: %w", err)
+	}
+	defer gr.Close()
+
+	var logs []Log
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("read tar archive: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg || !isSARIFFile(hdr.Name) {
+			continue
+		}
+
+		ls, err := DecodeAll(tr, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("archive entry %v: %w", hdr.Name, err)
+		}
+		logs = append(logs, ls...)
+	}
+	return logs, nil
+}
+
+// isSARIFFile reports whether name has the extension ".sarif" or
+// ".sarif.json".
+func isSARIFFile(name string) bool {
+	base := strings.ToLower(path.Base(name))
+	return strings.HasSuffix(base, ".sarif") || strings.HasSuffix(base, ".sarif.json")
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// archiveEntries are the entries of the archives used for testing.
+var archiveEntries = []struct {
+	name string
+	data string
+}{
+	{"results/a.sarif", `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "a"}}}]}`},
+	{"README.md", `# Results`},
+	{"results/b.SARIF.json", `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "b"}}}]}`},
+	{"results/c.json", `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "c"}}}]}`},
+}
+
+func TestDecodeArchive(t *testing.T) {
+	tmpdir := t.TempDir()
+
+	zipPath := filepath.Join(tmpdir, "results.zip")
+	writeZip(t, zipPath)
+
+	tgzPath := filepath.Join(tmpdir, "results.tar.gz")
+	writeTarGz(t, tgzPath)
+
+	otherPath := filepath.Join(tmpdir, "results.txt")
+	if err := os.WriteFile(otherPath, []byte("not an archive"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantNames  []string
+		wantNilErr bool
+	}{
+		{
+			name:       "zip",
+			path:       zipPath,
+			wantNames:  []string{"a", "b"},
+			wantNilErr: true,
+		},
+		{
+			name:       "tar.gz",
+			path:       tgzPath,
+			wantNames:  []string{"a", "b"},
+			wantNilErr: true,
+		},
+		{
+			name:       "unknown format",
+			path:       otherPath,
+			wantNilErr: false,
+		},
+		{
+			name:       "invalid path",
+			path:       filepath.Join(tmpdir, "missing.zip"),
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, err := DecodeArchive(tt.path)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			var names []string
+			for _, l := range logs {
+				names = append(names, l.Runs[0].Tool.Driver.Name)
+			}
+			if diff := cmp.Diff(tt.wantNames, names); diff != "" {
+				t.Errorf("names mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func writeZip(t *testing.T, name string) {
+	t.Helper()
+
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatalf("create zip file: %v", err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, e := range archiveEntries {
+		w, err := zw.Create(e.name)
+		if err != nil {
+			t.Fatalf("create zip entry: %v", err)
+		}
+		if _, err := w.Write([]byte(e.data)); err != nil {
+			t.Fatalf("write zip entry: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip writer: %v", err)
+	}
+}
+
+func writeTarGz(t *testing.T, name string) {
+	t.Helper()
+
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatalf("create tar.gz file: %v", err)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for _, e := range archiveEntries {
+		hdr := &tar.Header{
+			Name: e.name,
+			Mode: 0o644,
+			Size: int64(len(e.data)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(e.data)); err != nil {
+			t.Fatalf("write tar entry: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar writer: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("close gzip writer: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ColumnUnit is a unit used to measure column numbers.
+type ColumnUnit int
+
+// Supported column units.
+const (
+	// ColumnBytes measures columns in bytes, as most Go tools
+	// do.
+	ColumnBytes ColumnUnit = iota
+
+	// ColumnRunes measures columns in Unicode code points.
+	ColumnRunes
+
+	// ColumnUTF16 measures columns in UTF-16 code units, which is
+	// the SARIF default.
+	ColumnUTF16
+)
+
+// valid reports whether the column unit is supported.
+func (unit ColumnUnit) valid() bool {
+	return unit >= ColumnBytes && unit <= ColumnUTF16
+}
+
+// ColumnKind specifies the unit in which a [Run] measures columns.
+type ColumnKind string
+
+// Supported column kinds.
+const (
+	// ColumnKindUTF16CodeUnits measures columns in UTF-16 code
+	// units.
+	ColumnKindUTF16CodeUnits ColumnKind = "utf16CodeUnits"
+
+	// ColumnKindUnicodeCodePoints measures columns in Unicode code
+	// points.
+	ColumnKindUnicodeCodePoints ColumnKind = "unicodeCodePoints"
+)
+
+// Unit returns the [ColumnUnit] corresponding to the column kind. An
+// empty or unknown column kind is treated as
+// [ColumnKindUTF16CodeUnits], which is the SARIF default.
+func (kind ColumnKind) Unit() ColumnUnit {
+	if kind == ColumnKindUnicodeCodePoints {
+		return ColumnRunes
+	}
+	return ColumnUTF16
+}
+
+// ConvertColumn converts the column number col of the specified line
+// of content from the unit from to the unit to. Line and column
+// numbers are 1-based. The column following the last character of
+// the line is valid, so end columns can be converted. It returns
+// error if the line does not exist or the column does not correspond
+// to the start of a character, or if from or to are not supported
+// column units.
+func ConvertColumn(content []byte, line, col int, from, to ColumnUnit) (int, error) {
+	if !from.valid() {
+		return 0, fmt.Errorf("invalid column unit: %v", from)
+	}
+	if !to.valid() {
+		return 0, fmt.Errorf("invalid column unit: %v", to)
+	}
+
+	text, err := lineText(content, line)
+	if err != nil {
+		return 0, err
+	}
+	if col < 1 {
+		return 0, fmt.Errorf("invalid column: %v", col)
+	}
+
+	var off [ColumnUTF16 + 1]int
+	for {
+		if off[from] == col-1 {
+			return off[to] + 1, nil
+		}
+		if off[from] > col-1 || len(text) == 0 {
+			return 0, fmt.Errorf("invalid column: %v", col)
+		}
+
+		r, size := utf8.DecodeRune(text)
+		text = text[size:]
+		off[ColumnBytes] += size
+		off[ColumnRunes]++
+		off[ColumnUTF16] += utf16Len(r)
+	}
+}
+
+// lineText returns the specified 1-based line of content without the
+// line terminator.
+func lineText(content []byte, line int) ([]byte, error) {
+	if line < 1 {
+		return nil, fmt.Errorf("invalid line: %v", line)
+	}
+	for i := 1; i < line; i++ {
+		_, rest, found := bytes.Cut(content, []byte("\n"))
+		if !found {
+			return nil, errors.New("line out of range")
+		}
+		content = rest
+	}
+	text, _, _ := bytes.Cut(content, []byte("\n"))
+	return bytes.TrimSuffix(text, []byte("\r")), nil
+}
+
+// utf16Len returns the number of UTF-16 code units required to encode
+// r.
+func utf16Len(r rune) int {
+	if utf16.IsSurrogate(r) || r < 0x10000 {
+		return 1
+	}
+	return 2
+}
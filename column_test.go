@@ -0,0 +1,171 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+)
+
+func TestConvertColumn(t *testing.T) {
+	content := []byte("package main\r\n// ñ 😀 x\n漢字x")
+
+	tests := []struct {
+		name       string
+		line       int
+		col        int
+		from       ColumnUnit
+		to         ColumnUnit
+		want       int
+		wantNilErr bool
+	}{
+		{
+			name:       "ascii",
+			line:       1,
+			col:        9,
+			from:       ColumnBytes,
+			to:         ColumnUTF16,
+			want:       9,
+			wantNilErr: true,
+		},
+		{
+			name:       "end of line",
+			line:       1,
+			col:        13,
+			from:       ColumnUTF16,
+			to:         ColumnBytes,
+			want:       13,
+			wantNilErr: true,
+		},
+		{
+			name:       "bytes to utf16",
+			line:       2,
+			col:        11,
+			from:       ColumnBytes,
+			to:         ColumnUTF16,
+			want:       8,
+			wantNilErr: true,
+		},
+		{
+			name:       "utf16 to runes",
+			line:       2,
+			col:        8,
+			from:       ColumnUTF16,
+			to:         ColumnRunes,
+			want:       7,
+			wantNilErr: true,
+		},
+		{
+			name:       "runes to bytes",
+			line:       3,
+			col:        3,
+			from:       ColumnRunes,
+			to:         ColumnBytes,
+			want:       7,
+			wantNilErr: true,
+		},
+		{
+			name:       "same unit",
+			line:       3,
+			col:        2,
+			from:       ColumnRunes,
+			to:         ColumnRunes,
+			want:       2,
+			wantNilErr: true,
+		},
+		{
+			name:       "inside character",
+			line:       3,
+			col:        2,
+			from:       ColumnBytes,
+			to:         ColumnRunes,
+			wantNilErr: false,
+		},
+		{
+			name:       "inside surrogate pair",
+			line:       2,
+			col:        7,
+			from:       ColumnUTF16,
+			to:         ColumnBytes,
+			wantNilErr: false,
+		},
+		{
+			name:       "past end of line",
+			line:       1,
+			col:        14,
+			from:       ColumnBytes,
+			to:         ColumnUTF16,
+			wantNilErr: false,
+		},
+		{
+			name:       "line out of range",
+			line:       4,
+			col:        1,
+			from:       ColumnBytes,
+			to:         ColumnUTF16,
+			wantNilErr: false,
+		},
+		{
+			name:       "invalid column",
+			line:       1,
+			col:        0,
+			from:       ColumnBytes,
+			to:         ColumnUTF16,
+			wantNilErr: false,
+		},
+		{
+			name:       "invalid from unit",
+			line:       1,
+			col:        1,
+			from:       ColumnUnit(-1),
+			to:         ColumnUTF16,
+			wantNilErr: false,
+		},
+		{
+			name:       "invalid to unit",
+			line:       1,
+			col:        1,
+			from:       ColumnBytes,
+			to:         ColumnUTF16 + 1,
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col, err := ConvertColumn(content, tt.line, tt.col, tt.from, tt.to)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if col != tt.want {
+				t.Errorf("column mismatch: want: %v, got: %v", tt.want, col)
+			}
+		})
+	}
+}
+
+func TestColumnKind_Unit(t *testing.T) {
+	tests := []struct {
+		kind ColumnKind
+		want ColumnUnit
+	}{
+		{kind: ColumnKindUTF16CodeUnits, want: ColumnUTF16},
+		{kind: ColumnKindUnicodeCodePoints, want: ColumnRunes},
+		{kind: "", want: ColumnUTF16},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			if got := tt.kind.Unit(); got != tt.want {
+				t.Errorf("unit mismatch: want: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+)
+
+// PopulateContextRegions returns a copy of the provided [Log] where
+// the physical location of every result location with a region has a
+// context region. The context region spans linesBefore lines before
+// the region and linesAfter lines after it, and its snippet contains
+// the text of those lines.
+//
+// Artifacts are read from fsys using the path of the artifact URI.
+// Base URI identifiers are ignored, so fsys is expected to be rooted
+// at the directory the artifact URIs are relative to. Locations whose
+// artifact does not exist in fsys, including those with absolute
+// paths, paths outside of fsys or URIs with a scheme different from
+// "file", are left untouched.
+func PopulateContextRegions(l Log, fsys fs.FS, linesBefore, linesAfter int) (Log, error) {
+	linesBefore = max(linesBefore, 0)
+	linesAfter = max(linesAfter, 0)
+
+	contents := make(map[string][]byte)
+	l.Runs = slices.Clone(l.Runs)
+	for i := range l.Runs {
+		run := &l.Runs[i]
+		run.Results = slices.Clone(run.Results)
+		for j := range run.Results {
+			result := &run.Results[j]
+			result.Locations = slices.Clone(result.Locations)
+			for k := range result.Locations {
+				ploc := &result.Locations[k].PhysicalLocation
+				if ploc.Region.StartLine == 0 {
+					continue
+				}
+
+				content, err := readArtifact(fsys, ploc.ArtifactLocation, contents)
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				if err != nil {
+					return Log{}, err
+				}
+
+				if cr, ok := contextRegion(content, ploc.Region, linesBefore, linesAfter); ok {
+					ploc.ContextRegion = &cr
+				}
+			}
+		}
+	}
+	return l, nil
+}
+
+// readArtifact returns the content of the artifact at the provided
+// location in fsys. Contents are cached in the provided map, which is
+// keyed by path. If the artifact URI has a scheme different from
+// "file" or its path is not a valid path in fsys, the returned error
+// wraps [fs.ErrNotExist].
+func readArtifact(fsys fs.FS, loc ArtifactLocation, cache map[string][]byte) ([]byte, error) {
+	p, err := loc.Path()
+	if errors.Is(err, errUnsupportedScheme) {
+		return nil, fmt.Errorf("artifact outside of the file system: %w: %w", err, fs.ErrNotExist)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p = filepath.ToSlash(p)
+
+	if content, ok := cache[p]; ok {
+		return content, nil
+	}
+	if !fs.ValidPath(p) {
+		return nil, fmt.Errorf("artifact path outside of the file system: %v: %w", p, fs.ErrNotExist)
+	}
+	content, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return nil, fmt.Errorf("read artifact: %w", err)
+	}
+	cache[p] = content
+	return content, nil
+}
+
+// contextRegion returns the region of content spanning linesBefore
+// lines before region and linesAfter lines after it. It returns false
+// if region is not part of content.
+func contextRegion(content []byte, region Region, linesBefore, linesAfter int) (Region, bool) {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	endLine := max(region.EndLine, region.StartLine)
+	if region.StartLine < 1 || endLine > len(lines) {
+		return Region{}, false
+	}
+
+	start := max(region.StartLine-linesBefore, 1)
+	end := min(endLine+linesAfter, len(lines))
+	return Region{
+		StartLine: start,
+		EndLine:   end,
+		Snippet: &ArtifactContent{
+			Text: string(bytes.Join(lines[start-1:end], nil)),
+		},
+	}, true
+}
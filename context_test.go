@@ -0,0 +1,157 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPopulateContextRegions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"src/main.go": {Data: []byte("line 1\nline 2\nline 3\nline 4\nline 5\n")},
+	}
+
+	tests := []struct {
+		name       string
+		uri        string
+		region     Region
+		before     int
+		after      int
+		want       *Region
+		wantNilErr bool
+	}{
+		{
+			name:   "middle",
+			uri:    "src/main.go",
+			region: Region{StartLine: 3},
+			before: 1,
+			after:  1,
+			want: &Region{
+				StartLine: 2,
+				EndLine:   4,
+				Snippet:   &ArtifactContent{Text: "line 2\nline 3\nline 4\n"},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:   "clamped",
+			uri:    "src/main.go",
+			region: Region{StartLine: 1, EndLine: 4},
+			before: 2,
+			after:  3,
+			want: &Region{
+				StartLine: 1,
+				EndLine:   5,
+				Snippet:   &ArtifactContent{Text: "line 1\nline 2\nline 3\nline 4\nline 5\n"},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:       "no region",
+			uri:        "src/main.go",
+			region:     Region{},
+			before:     1,
+			after:      1,
+			want:       nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "region out of range",
+			uri:        "src/main.go",
+			region:     Region{StartLine: 6},
+			before:     1,
+			after:      1,
+			want:       nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "missing artifact",
+			uri:        "src/other.go",
+			region:     Region{StartLine: 1},
+			before:     1,
+			after:      1,
+			want:       nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "absolute URI",
+			uri:        "file:///usr/src/main.go",
+			region:     Region{StartLine: 1},
+			before:     1,
+			after:      1,
+			want:       nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "outside of fsys",
+			uri:        "../main.go",
+			region:     Region{StartLine: 1},
+			before:     1,
+			after:      1,
+			want:       nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "non-file URI",
+			uri:        "https://example.com/main.go",
+			region:     Region{StartLine: 1},
+			before:     1,
+			after:      1,
+			want:       nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "malformed URI",
+			uri:        "src/a b.go",
+			region:     Region{StartLine: 1},
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Log{
+				Runs: []Run{
+					{
+						Results: []Result{
+							{
+								Locations: []Location{
+									{
+										PhysicalLocation: PhysicalLocation{
+											ArtifactLocation: ArtifactLocation{URI: tt.uri},
+											Region:           tt.region,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			got, err := PopulateContextRegions(l, fsys, tt.before, tt.after)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			cr := got.Runs[0].Results[0].Locations[0].PhysicalLocation.ContextRegion
+			if diff := cmp.Diff(tt.want, cr); diff != "" {
+				t.Errorf("context region mismatch (-want +got):\n%v", diff)
+			}
+
+			if l.Runs[0].Results[0].Locations[0].PhysicalLocation.ContextRegion != nil {
+				t.Errorf("original log was modified")
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// ErrUnsupportedConversion is returned by converters that do not
+// support one of the conversion directions.
+var ErrUnsupportedConversion = errors.New("unsupported conversion")
+
+// Converter converts between SARIF and the native output format of a
+// tool.
+type Converter interface {
+	// FromNative reads a document in the native format from r and
+	// converts it into a [Log].
+	FromNative(r io.Reader) (Log, error)
+
+	// ToNative converts the [Log] into the native format and
+	// writes the result to w.
+	ToNative(l Log, w io.Writer) error
+}
+
+var (
+	convertersMu sync.RWMutex
+	converters   = make(map[string]Converter)
+)
+
+func init() {
+	RegisterConverter("sarif", sarifConverter{})
+}
+
+// RegisterConverter makes a converter available by the provided
+// format name. If RegisterConverter is called twice with the same
+// name or if the converter is nil, it panics.
+func RegisterConverter(name string, c Converter) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	if c == nil {
+		panic("sarif: register nil converter")
+	}
+	if _, dup := converters[name]; dup {
+		panic(fmt.Sprintf("sarif: register converter twice: %v", name))
+	}
+	converters[name] = c
+}
+
+// LookupConverter returns the converter registered with the provided
+// format name.
+func LookupConverter(name string) (c Converter, found bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+
+	c, found = converters[name]
+	return c, found
+}
+
+// Converters returns the sorted list of the format names of the
+// registered converters.
+func Converters() []string {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+
+	var names []string
+	for name := range converters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// sarifConverter is the identity converter. It is registered with the
+// name "sarif".
+type sarifConverter struct{}
+
+// FromNative decodes the SARIF document read from r.
+func (sarifConverter) FromNative(r io.Reader) (Log, error) {
+	return Decode(r)
+}
+
+// ToNative encodes the [Log] as a SARIF document.
+func (sarifConverter) ToNative(l Log, w io.Writer) error {
+	return l.Encode(w)
+}
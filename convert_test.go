@@ -0,0 +1,81 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type testConverter struct{}
+
+func (testConverter) FromNative(r io.Reader) (Log, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return Log{}, err
+	}
+	return Log{Runs: []Run{{Tool: Tool{Driver: Driver{Name: string(b)}}}}}, nil
+}
+
+func (testConverter) ToNative(l Log, w io.Writer) error {
+	return ErrUnsupportedConversion
+}
+
+func TestRegisterConverter(t *testing.T) {
+	RegisterConverter("test", testConverter{})
+	t.Cleanup(func() {
+		convertersMu.Lock()
+		delete(converters, "test")
+		convertersMu.Unlock()
+	})
+
+	if !slices.Contains(Converters(), "test") {
+		t.Fatalf("converter not listed: %v", Converters())
+	}
+
+	c, found := LookupConverter("test")
+	if !found {
+		t.Fatalf("converter not found")
+	}
+	l, err := c.FromNative(strings.NewReader("tool"))
+	if err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+	if name := l.Runs[0].Tool.Driver.Name; name != "tool" {
+		t.Errorf("tool name mismatch: got: %v", name)
+	}
+
+	if _, found := LookupConverter("unknown"); found {
+		t.Errorf("unexpected converter")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic registering duplicate converter")
+		}
+	}()
+	RegisterConverter("test", testConverter{})
+}
+
+func TestSARIFConverter(t *testing.T) {
+	c, found := LookupConverter("sarif")
+	if !found {
+		t.Fatalf("converter not found")
+	}
+
+	l, err := c.FromNative(strings.NewReader(`{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "a"}}}]}`))
+	if err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := c.ToNative(l, buf); err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"name": "a"`) {
+		t.Errorf("unexpected document:\n%v", buf)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+)
+
+// ExternalPropertyFileReferences contains references to the external
+// property files holding parts of a [Run].
+type ExternalPropertyFileReferences struct {
+	// Conversion refers to the file containing the conversion
+	// object of the run.
+	Conversion *ExternalPropertyFileReference `json:"conversion,omitempty"`
+
+	// Graphs refers to the files containing graphs of the run.
+	Graphs []ExternalPropertyFileReference `json:"graphs,omitempty"`
+
+	// ExternalizedProperties refers to the file containing the
+	// properties of the run.
+	ExternalizedProperties *ExternalPropertyFileReference `json:"externalizedProperties,omitempty"`
+
+	// Artifacts refers to the files containing artifacts of the
+	// run.
+	Artifacts []ExternalPropertyFileReference `json:"artifacts,omitempty"`
+
+	// Invocations refers to the files containing invocations of
+	// the run.
+	Invocations []ExternalPropertyFileReference `json:"invocations,omitempty"`
+
+	// Results refers to the files containing results of the run.
+	Results []ExternalPropertyFileReference `json:"results,omitempty"`
+
+	// Taxonomies refers to the files containing taxonomies of the
+	// run.
+	Taxonomies []ExternalPropertyFileReference `json:"taxonomies,omitempty"`
+
+	// Driver refers to the file containing the driver of the run.
+	Driver *ExternalPropertyFileReference `json:"driver,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// ExternalPropertyFileReference identifies an external property file.
+type ExternalPropertyFileReference struct {
+	// Location is the location of the external property file.
+	Location ArtifactLocation `json:"location,omitempty"`
+
+	// GUID is the GUID of the external property file. If it is
+	// not empty, it must match [ExternalProperties.GUID].
+	GUID string `json:"guid,omitempty"`
+
+	// ItemCount is the number of items contained in the external
+	// property file.
+	ItemCount int `json:"itemCount,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// ExternalProperties is the root object of an external property file.
+// It contains parts of a [Run] that are stored outside of the SARIF
+// document.
+type ExternalProperties struct {
+	// Schema is the URI of the JSON schema of the external
+	// property file.
+	Schema string `json:"schema,omitempty"`
+
+	// Version is the version of the SARIF specification to which
+	// the external property file conforms.
+	Version string `json:"version,omitempty"`
+
+	// GUID is a unique identifier for the external property file
+	// in the form of a GUID.
+	GUID string `json:"guid,omitempty"`
+
+	// RunGUID is the GUID of the run the external property file
+	// belongs to.
+	RunGUID string `json:"runGuid,omitempty"`
+
+	// Conversion is the conversion object of the run.
+	Conversion *Conversion `json:"conversion,omitempty"`
+
+	// Graphs contains graphs of the run.
+	Graphs []Graph `json:"graphs,omitempty"`
+
+	// ExternalizedProperties contains properties of the run.
+	ExternalizedProperties map[string]any `json:"externalizedProperties,omitempty"`
+
+	// Artifacts contains artifacts of the run.
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+
+	// Invocations contains invocations of the run.
+	Invocations []Invocation `json:"invocations,omitempty"`
+
+	// Results contains results of the run.
+	Results []Result `json:"results,omitempty"`
+
+	// Taxonomies contains taxonomies of the run.
+	Taxonomies []Driver `json:"taxonomies,omitempty"`
+
+	// Driver is the driver of the run.
+	Driver *Driver `json:"driver,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// ResolveExternalProperties returns a copy of the provided [Log] where
+// the contents of the external property files referenced by every run
+// are merged into the run. Externalized graphs, artifacts,
+// invocations, results and taxonomies are appended to the ones of the
+// run in the order in which the files are referenced. Externalized
+// properties are added to the properties of the run. The rules,
+// notifications and taxa of an externalized driver are appended to
+// the ones of the driver of the run, and its name, version and
+// information URI are used if the run does not set them. The
+// conversion object is only used if the run does not have one. The
+// references are removed from the returned log.
+//
+// External property files are read from fsys as described in
+// [PopulateContextRegions]. It returns error if a file cannot be read
+// or decoded, or if its GUID does not match the reference.
+func (l Log) ResolveExternalProperties(fsys fs.FS) (Log, error) {
+	files := make(map[string]ExternalProperties)
+	read := func(ref ExternalPropertyFileReference) (ExternalProperties, error) {
+		p, err := ref.Location.Path()
+		if err != nil {
+			return ExternalProperties{}, err
+		}
+		p = filepath.ToSlash(p)
+
+		ext, ok := files[p]
+		if !ok {
+			if ext, err = readExternalProperties(fsys, p); err != nil {
+				return ExternalProperties{}, fmt.Errorf("external property file %v: %w", p, err)
+			}
+			files[p] = ext
+		}
+		if ref.GUID != "" && ref.GUID != ext.GUID {
+			return ExternalProperties{}, fmt.Errorf("external property file %v: GUID mismatch: %v", p, ext.GUID)
+		}
+		return ext, nil
+	}
+
+	runs := make([]Run, len(l.Runs))
+	for i, run := range l.Runs {
+		refs := run.ExternalPropertyFileReferences
+		if refs == nil {
+			runs[i] = run
+			continue
+		}
+		run.ExternalPropertyFileReferences = nil
+
+		if refs.Conversion != nil {
+			ext, err := read(*refs.Conversion)
+			if err != nil {
+				return Log{}, err
+			}
+			if run.Conversion == nil {
+				run.Conversion = ext.Conversion
+			}
+		}
+
+		for _, ref := range refs.Graphs {
+			ext, err := read(ref)
+			if err != nil {
+				return Log{}, err
+			}
+			run.Graphs = append(slices.Clip(run.Graphs), ext.Graphs...)
+		}
+
+		if refs.ExternalizedProperties != nil {
+			ext, err := read(*refs.ExternalizedProperties)
+			if err != nil {
+				return Log{}, err
+			}
+			if len(ext.ExternalizedProperties) > 0 {
+				run.Properties = maps.Clone(run.Properties)
+				if run.Properties == nil {
+					run.Properties = make(map[string]any)
+				}
+				maps.Copy(run.Properties, ext.ExternalizedProperties)
+			}
+		}
+
+		for _, ref := range refs.Artifacts {
+			ext, err := read(ref)
+			if err != nil {
+				return Log{}, err
+			}
+			run.Artifacts = append(slices.Clip(run.Artifacts), ext.Artifacts...)
+		}
+
+		for _, ref := range refs.Invocations {
+			ext, err := read(ref)
+			if err != nil {
+				return Log{}, err
+			}
+			run.Invocations = append(slices.Clip(run.Invocations), ext.Invocations...)
+		}
+
+		for _, ref := range refs.Results {
+			ext, err := read(ref)
+			if err != nil {
+				return Log{}, err
+			}
+			run.Results = append(slices.Clip(run.Results), ext.Results...)
+		}
+
+		for _, ref := range refs.Taxonomies {
+			ext, err := read(ref)
+			if err != nil {
+				return Log{}, err
+			}
+			run.Taxonomies = append(slices.Clip(run.Taxonomies), ext.Taxonomies...)
+		}
+
+		if refs.Driver != nil {
+			ext, err := read(*refs.Driver)
+			if err != nil {
+				return Log{}, err
+			}
+			if ext.Driver != nil {
+				run.Tool.Driver = mergeDriver(run.Tool.Driver, *ext.Driver)
+			}
+		}
+
+		runs[i] = run
+	}
+	l.Runs = runs
+	return l, nil
+}
+
+// readExternalProperties reads and decodes the external property file
+// with the provided path in fsys.
+func readExternalProperties(fsys fs.FS, p string) (ExternalProperties, error) {
+	if !fs.ValidPath(p) {
+		return ExternalProperties{}, fmt.Errorf("invalid path: %v", p)
+	}
+	b, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return ExternalProperties{}, fmt.Errorf("read file: %w", err)
+	}
+
+	var ext ExternalProperties
+	if err := json.NewDecoder(bytes.NewReader(b)).Decode(&ext); err != nil {
+		return ExternalProperties{}, fmt.Errorf("decode external properties: %w", err)
+	}
+	if ext.Version != "" && ext.Version != sarifVersion {
+		return ExternalProperties{}, fmt.Errorf("unsupported SARIF version: %v", ext.Version)
+	}
+	return ext, nil
+}
+
+// mergeDriver returns the result of merging the externalized driver
+// ext into driver. See [Log.ResolveExternalProperties].
+func mergeDriver(driver, ext Driver) Driver {
+	if driver.Name == "" {
+		driver.Name = ext.Name
+	}
+	if driver.Version == "" {
+		driver.Version = ext.Version
+	}
+	if driver.InformationURI == "" {
+		driver.InformationURI = ext.InformationURI
+	}
+	driver.Rules = append(slices.Clip(driver.Rules), ext.Rules...)
+	driver.Notifications = append(slices.Clip(driver.Notifications), ext.Notifications...)
+	driver.Taxa = append(slices.Clip(driver.Taxa), ext.Taxa...)
+	return driver
+}
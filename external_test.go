@@ -0,0 +1,203 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLog_ResolveExternalProperties(t *testing.T) {
+	fsys := fstest.MapFS{
+		"ext/results.sarif-external-properties": {Data: []byte(`{
+			"version": "2.1.0",
+			"guid": "11111111-1111-4111-8111-111111111111",
+			"results": [{"ruleId": "R2", "message": {"text": "External."}}]
+		}`)},
+		"ext/rest.sarif-external-properties": {Data: []byte(`{
+			"version": "2.1.0",
+			"driver": {"name": "ignored", "semanticVersion": "1.0.0", "rules": [{"id": "R2"}]},
+			"artifacts": [{"location": {"uri": "b.go"}}],
+			"invocations": [{"executionSuccessful": true}],
+			"externalizedProperties": {"commit": "abc"}
+		}`)},
+		"ext/v1.sarif-external-properties": {Data: []byte(`{"version": "1.0.0"}`)},
+	}
+
+	tests := []struct {
+		name       string
+		l          Log
+		want       Log
+		wantNilErr bool
+	}{
+		{
+			name: "merge",
+			l: Log{
+				Version: "2.1.0",
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{Name: "scanner", Rules: []Rule{{ID: "R1"}}},
+						},
+						Artifacts: []Artifact{{Location: ArtifactLocation{URI: "a.go"}}},
+						Results:   []Result{{RuleID: "R1"}},
+						ExternalPropertyFileReferences: &ExternalPropertyFileReferences{
+							Results: []ExternalPropertyFileReference{
+								{
+									Location: ArtifactLocation{URI: "ext/results.sarif-external-properties"},
+									GUID:     "11111111-1111-4111-8111-111111111111",
+								},
+							},
+							Artifacts: []ExternalPropertyFileReference{
+								{Location: ArtifactLocation{URI: "ext/rest.sarif-external-properties"}},
+							},
+							Invocations: []ExternalPropertyFileReference{
+								{Location: ArtifactLocation{URI: "ext/rest.sarif-external-properties"}},
+							},
+							Driver:                 &ExternalPropertyFileReference{Location: ArtifactLocation{URI: "ext/rest.sarif-external-properties"}},
+							ExternalizedProperties: &ExternalPropertyFileReference{Location: ArtifactLocation{URI: "ext/rest.sarif-external-properties"}},
+						},
+					},
+				},
+			},
+			want: Log{
+				Version: "2.1.0",
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{
+								Name:    "scanner",
+								Version: "1.0.0",
+								Rules:   []Rule{{ID: "R1"}, {ID: "R2"}},
+							},
+						},
+						Artifacts: []Artifact{
+							{Location: ArtifactLocation{URI: "a.go"}},
+							{Location: ArtifactLocation{URI: "b.go"}},
+						},
+						Invocations: []Invocation{{ExecutionSuccessful: true}},
+						Results: []Result{
+							{RuleID: "R1"},
+							{RuleID: "R2", Message: Description{Text: "External."}},
+						},
+						Properties: map[string]any{"commit": "abc"},
+					},
+				},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "no references",
+			l: Log{
+				Version: "2.1.0",
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{Name: "scanner", Rules: []Rule{{ID: "R1"}}},
+						},
+						Artifacts: []Artifact{{Location: ArtifactLocation{URI: "a.go"}}},
+						Results:   []Result{{RuleID: "R1"}},
+					},
+				},
+			},
+			want: Log{
+				Version: "2.1.0",
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{Name: "scanner", Rules: []Rule{{ID: "R1"}}},
+						},
+						Artifacts: []Artifact{{Location: ArtifactLocation{URI: "a.go"}}},
+						Results:   []Result{{RuleID: "R1"}},
+					},
+				},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "GUID mismatch",
+			l: Log{
+				Version: "2.1.0",
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{Name: "scanner", Rules: []Rule{{ID: "R1"}}},
+						},
+						Artifacts: []Artifact{{Location: ArtifactLocation{URI: "a.go"}}},
+						Results:   []Result{{RuleID: "R1"}},
+						ExternalPropertyFileReferences: &ExternalPropertyFileReferences{
+							Results: []ExternalPropertyFileReference{
+								{Location: ArtifactLocation{URI: "ext/results.sarif-external-properties"}, GUID: "22222222-2222-4222-8222-222222222222"},
+							},
+						},
+					},
+				},
+			},
+			wantNilErr: false,
+		},
+		{
+			name: "missing file",
+			l: Log{
+				Version: "2.1.0",
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{Name: "scanner", Rules: []Rule{{ID: "R1"}}},
+						},
+						Artifacts: []Artifact{{Location: ArtifactLocation{URI: "a.go"}}},
+						Results:   []Result{{RuleID: "R1"}},
+						ExternalPropertyFileReferences: &ExternalPropertyFileReferences{
+							Results: []ExternalPropertyFileReference{
+								{Location: ArtifactLocation{URI: "ext/missing.sarif-external-properties"}},
+							},
+						},
+					},
+				},
+			},
+			wantNilErr: false,
+		},
+		{
+			name: "unsupported version",
+			l: Log{
+				Version: "2.1.0",
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{Name: "scanner", Rules: []Rule{{ID: "R1"}}},
+						},
+						Artifacts: []Artifact{{Location: ArtifactLocation{URI: "a.go"}}},
+						Results:   []Result{{RuleID: "R1"}},
+						ExternalPropertyFileReferences: &ExternalPropertyFileReferences{
+							Results: []ExternalPropertyFileReference{
+								{Location: ArtifactLocation{URI: "ext/v1.sarif-external-properties"}},
+							},
+						},
+					},
+				},
+			},
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.l.ResolveExternalProperties(fsys)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("log mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// guidNamespace is the namespace of the name-based GUIDs generated by
+// this package.
+var guidNamespace = [16]byte{
+	0x6b, 0x1f, 0x5e, 0x34, 0x2c, 0x9a, 0x4d, 0x0e,
+	0x8f, 0x71, 0x3b, 0x52, 0xa4, 0xd6, 0x19, 0xc8,
+}
+
+// StableGUID returns a GUID derived from the rule identifier, the
+// first physical location and the message text of the result, so the
+// same result reported by different runs gets the same GUID. The
+// provided rule should be the rule that was evaluated to produce the
+// result. See [Run.ResultRule]. If it is not known, the rule
+// identifier referenced by the result is used. It is a name-based
+// version 5 UUID as described in RFC 9562. It is meant to be used as
+// [Result.CorrelationGUID], not as [Result.GUID], which must be
+// unique. Results that only differ in other properties get the same
+// GUID.
+func (r Result) StableGUID(rule Rule) string {
+	loc := firstPhysicalLocation(r)
+	return nameGUID(
+		r.ruleID(rule),
+		loc.ArtifactLocation.URIBaseID,
+		loc.ArtifactLocation.URI,
+		strconv.Itoa(loc.Region.StartLine),
+		strconv.Itoa(loc.Region.StartColumn),
+		r.Message.Text,
+	)
+}
+
+// AssignGUIDs returns a copy of the provided [Log] where every result
+// without [Result.GUID] gets a random GUID, which uniquely identifies
+// the result object, and every result without
+// [Result.CorrelationGUID] gets the one returned by
+// [Result.StableGUID] for the rule resolved by [Run.ResultRule],
+// which is shared by the results that represent the same problem
+// across runs. Existing GUIDs are kept.
+func (l Log) AssignGUIDs() Log {
+	runs := make([]Run, len(l.Runs))
+	for i, run := range l.Runs {
+		results := slices.Clone(run.Results)
+		for j, result := range results {
+			if result.GUID == "" {
+				results[j].GUID = randomGUID()
+			}
+			if result.CorrelationGUID == "" {
+				rule, _ := run.ResultRule(result)
+				results[j].CorrelationGUID = result.StableGUID(rule)
+			}
+		}
+		run.Results = results
+		runs[i] = run
+	}
+	l.Runs = runs
+	return l
+}
+
+// randomGUID returns a random version 4 UUID as described in RFC
+// 9562. It panics if the system random number generator fails.
+func randomGUID() string {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		panic(fmt.Sprintf("generate random GUID: %v", err))
+	}
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return formatGUID(u)
+}
+
+// nameGUID returns the version 5 UUID of the provided name components
+// in the namespace of the package.
+func nameGUID(components ...string) string {
+	h := sha1.New()
+	h.Write(guidNamespace[:])
+	for _, c := range components {
+		// Length-prefix every component, so different splits of
+		// the same string produce different GUIDs.
+		fmt.Fprintf(h, "%d:%s", len(c), c)
+	}
+	sum := h.Sum(nil)
+
+	var u [16]byte
+	copy(u[:], sum)
+	u[6] = (u[6] & 0x0f) | 0x50
+	u[8] = (u[8] & 0x3f) | 0x80
+	return formatGUID(u)
+}
+
+// formatGUID returns the string representation of the provided UUID.
+func formatGUID(u [16]byte) string {
+	var b strings.Builder
+	for i, c := range u {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			b.WriteByte('-')
+		}
+		fmt.Fprintf(&b, "%02x", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,222 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	guidRegexp       = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	randomGUIDRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+)
+
+func TestResult_StableGUID(t *testing.T) {
+	guid := Result{
+		RuleID:  "R1",
+		Message: Description{Text: "message"},
+		Locations: []Location{
+			{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: "a.go"},
+					Region:           Region{StartLine: 1},
+				},
+			},
+		},
+	}.StableGUID(Rule{})
+	if !guidRegexp.MatchString(guid) {
+		t.Fatalf("invalid GUID: %v", guid)
+	}
+
+	tests := []struct {
+		name      string
+		result    Result
+		rule      Rule
+		wantEqual bool
+	}{
+		{
+			name: "same result",
+			result: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "message"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+				},
+			},
+			wantEqual: true,
+		},
+		{
+			name: "resolved rule",
+			result: Result{
+				Message: Description{Text: "message"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+				},
+			},
+			rule:      Rule{ID: "R1"},
+			wantEqual: true,
+		},
+		{
+			name: "rule reference",
+			result: Result{
+				Rule:    &ReportingDescriptorReference{ID: "R1"},
+				Message: Description{Text: "message"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+				},
+			},
+			wantEqual: true,
+		},
+		{
+			name: "different level",
+			result: Result{
+				RuleID:  "R1",
+				Level:   "error",
+				Message: Description{Text: "message"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+				},
+			},
+			wantEqual: true,
+		},
+		{
+			name: "different rule",
+			result: Result{
+				RuleID:  "R2",
+				Message: Description{Text: "message"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+				},
+			},
+			wantEqual: false,
+		},
+		{
+			name: "different artifact",
+			result: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "message"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "b.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+				},
+			},
+			wantEqual: false,
+		},
+		{
+			name: "different line",
+			result: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "message"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 2},
+						},
+					},
+				},
+			},
+			wantEqual: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.result.StableGUID(tt.rule)
+			if (got == guid) != tt.wantEqual {
+				t.Errorf("unexpected GUID comparison: got: %v, %v", got, guid)
+			}
+		})
+	}
+}
+
+func TestLog_AssignGUIDs(t *testing.T) {
+	l := Log{
+		Version: "2.1.0",
+		Runs: []Run{
+			{
+				Results: []Result{
+					{RuleID: "R1"},
+					{RuleID: "R1"},
+					{RuleID: "R2", GUID: "existing", CorrelationGUID: "existing-correlation"},
+				},
+			},
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Rules: []Rule{{ID: "R1"}},
+					},
+				},
+				Results: []Result{
+					{RuleIndex: ptr(0)},
+				},
+			},
+		},
+	}
+
+	got := l.AssignGUIDs()
+
+	seen := make(map[string]bool)
+	for _, run := range got.Runs {
+		for _, result := range run.Results {
+			if seen[result.GUID] {
+				t.Errorf("duplicated GUID: %v", result.GUID)
+			}
+			seen[result.GUID] = true
+		}
+	}
+
+	for _, result := range []Result{got.Runs[0].Results[0], got.Runs[0].Results[1], got.Runs[1].Results[0]} {
+		if !randomGUIDRegexp.MatchString(result.GUID) {
+			t.Errorf("invalid GUID: %v", result.GUID)
+		}
+		if want := l.Runs[0].Results[0].StableGUID(Rule{}); result.CorrelationGUID != want {
+			t.Errorf("correlation GUID mismatch: got: %v, want: %v", result.CorrelationGUID, want)
+		}
+	}
+
+	if diff := cmp.Diff("existing", got.Runs[0].Results[2].GUID); diff != "" {
+		t.Errorf("GUID mismatch (-want +got):\n%v", diff)
+	}
+	if diff := cmp.Diff("existing-correlation", got.Runs[0].Results[2].CorrelationGUID); diff != "" {
+		t.Errorf("correlation GUID mismatch (-want +got):\n%v", diff)
+	}
+	if again := l.AssignGUIDs(); again.Runs[0].Results[0].GUID == got.Runs[0].Results[0].GUID {
+		t.Errorf("GUID reused across calls: %v", again.Runs[0].Results[0].GUID)
+	}
+	if l.Runs[0].Results[0].GUID != "" || l.Runs[0].Results[0].CorrelationGUID != "" {
+		t.Errorf("input log was modified")
+	}
+}
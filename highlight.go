@@ -0,0 +1,169 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// HighlightKind specifies the role of a [Highlight].
+type HighlightKind string
+
+// Supported highlight kinds.
+const (
+	// HighlightPrimary is the kind of the highlights derived from
+	// [Result.Locations].
+	HighlightPrimary HighlightKind = "primary"
+
+	// HighlightRelated is the kind of the highlights derived from
+	// [Result.RelatedLocations].
+	HighlightRelated HighlightKind = "related"
+)
+
+// Highlight is a span of an artifact that an editor should highlight
+// for a result.
+type Highlight struct {
+	// Path is the path of the artifact, as returned by
+	// [ArtifactLocation.Path].
+	Path string
+
+	// Start is the byte offset of the first byte of the span.
+	Start int
+
+	// End is the byte offset following the last byte of the
+	// span.
+	End int
+
+	// Kind is the role of the span.
+	Kind HighlightKind
+
+	// Message is the text of the message of the location.
+	Message string
+}
+
+// Highlights returns the spans of the artifacts that correspond to
+// the regions of the locations and related locations of the result.
+// The start and end columns of the regions are measured in the
+// provided unit. A region without end line ends at its start line and
+// a region without end column ends at the end of its last line,
+// excluding the line terminator. Locations without region are
+// ignored.
+//
+// Artifacts are read from fsys as described in
+// [PopulateContextRegions]. Locations whose artifact does not exist in
+// fsys or whose artifact URI has a scheme different from "file" are
+// ignored.
+func (r Result) Highlights(fsys fs.FS, unit ColumnUnit) ([]Highlight, error) {
+	var highlights []Highlight
+	contents := make(map[string][]byte)
+	add := func(locs []Location, kind HighlightKind) error {
+		for _, loc := range locs {
+			ploc := loc.PhysicalLocation
+			if ploc.Region.StartLine == 0 {
+				continue
+			}
+
+			content, err := readArtifact(fsys, ploc.ArtifactLocation, contents)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			start, end, err := regionOffsets(content, ploc.Region, unit)
+			if err != nil {
+				return fmt.Errorf("region of %v: %w", ploc, err)
+			}
+
+			p, err := ploc.ArtifactLocation.Path()
+			if err != nil {
+				return err
+			}
+			highlights = append(highlights, Highlight{
+				Path:    p,
+				Start:   start,
+				End:     end,
+				Kind:    kind,
+				Message: loc.Message.Text,
+			})
+		}
+		return nil
+	}
+
+	if err := add(r.Locations, HighlightPrimary); err != nil {
+		return nil, err
+	}
+	if err := add(r.RelatedLocations, HighlightRelated); err != nil {
+		return nil, err
+	}
+	return highlights, nil
+}
+
+// regionOffsets returns the byte offsets of the start and the end of
+// the region in content. Columns are measured in the provided unit.
+func regionOffsets(content []byte, region Region, unit ColumnUnit) (start, end int, err error) {
+	startCol := max(region.StartColumn, 1)
+	start, err = columnOffset(content, region.StartLine, startCol, unit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	endLine := max(region.EndLine, region.StartLine)
+	if region.EndColumn == 0 {
+		off, err := lineOffset(content, endLine)
+		if err != nil {
+			return 0, 0, err
+		}
+		text, err := lineText(content, endLine)
+		if err != nil {
+			return 0, 0, err
+		}
+		end = off + len(text)
+	} else {
+		end, err = columnOffset(content, endLine, region.EndColumn, unit)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if end < start {
+		return 0, 0, errors.New("region ends before it starts")
+	}
+	return start, end, nil
+}
+
+// columnOffset returns the byte offset in content of the specified
+// 1-based line and column. The column is measured in the provided
+// unit.
+func columnOffset(content []byte, line, col int, unit ColumnUnit) (int, error) {
+	off, err := lineOffset(content, line)
+	if err != nil {
+		return 0, err
+	}
+	bcol, err := ConvertColumn(content, line, col, unit, ColumnBytes)
+	if err != nil {
+		return 0, err
+	}
+	return off + bcol - 1, nil
+}
+
+// lineOffset returns the byte offset in content of the first byte of
+// the specified 1-based line.
+func lineOffset(content []byte, line int) (int, error) {
+	if line < 1 {
+		return 0, fmt.Errorf("invalid line: %v", line)
+	}
+	off := 0
+	for i := 1; i < line; i++ {
+		n := bytes.IndexByte(content[off:], '\n')
+		if n < 0 {
+			return 0, errors.New("line out of range")
+		}
+		off += n + 1
+	}
+	return off, nil
+}
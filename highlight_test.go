@@ -0,0 +1,198 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResult_Highlights(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go": {Data: []byte("package main\n\nvar s = \"héllo\" // x\n")},
+		"util.go": {Data: []byte("package main\r\nfunc f() {}\r\n")},
+	}
+
+	tests := []struct {
+		name       string
+		result     Result
+		unit       ColumnUnit
+		want       []Highlight
+		wantNilErr bool
+	}{
+		{
+			name: "columns in bytes",
+			result: Result{
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "main.go"},
+							Region:           Region{StartLine: 3, StartColumn: 9, EndColumn: 17},
+						},
+					},
+				},
+			},
+			unit: ColumnBytes,
+			want: []Highlight{
+				{Path: "main.go", Start: 22, End: 30, Kind: HighlightPrimary},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "columns in UTF-16 code units",
+			result: Result{
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "main.go"},
+							Region:           Region{StartLine: 3, StartColumn: 17, EndColumn: 21},
+						},
+					},
+				},
+			},
+			unit: ColumnUTF16,
+			want: []Highlight{
+				{Path: "main.go", Start: 31, End: 35, Kind: HighlightPrimary},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "whole lines",
+			result: Result{
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "util.go"},
+							Region:           Region{StartLine: 1, EndLine: 2},
+						},
+						Message: Description{Text: "primary"},
+					},
+				},
+				RelatedLocations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "main.go"},
+							Region:           Region{StartLine: 1},
+						},
+						Message: Description{Text: "related"},
+					},
+				},
+			},
+			unit: ColumnUTF16,
+			want: []Highlight{
+				{Path: "util.go", Start: 0, End: 25, Kind: HighlightPrimary, Message: "primary"},
+				{Path: "main.go", Start: 0, End: 12, Kind: HighlightRelated, Message: "related"},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "ignored locations",
+			result: Result{
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "main.go"},
+						},
+					},
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "missing.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "https://example.com/main.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+				},
+			},
+			unit:       ColumnUTF16,
+			want:       nil,
+			wantNilErr: true,
+		},
+		{
+			name: "line out of range",
+			result: Result{
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "main.go"},
+							Region:           Region{StartLine: 10},
+						},
+					},
+				},
+			},
+			unit:       ColumnUTF16,
+			wantNilErr: false,
+		},
+		{
+			name: "invalid column",
+			result: Result{
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "main.go"},
+							Region:           Region{StartLine: 3, StartColumn: 12, EndColumn: 14},
+						},
+					},
+				},
+			},
+			unit:       ColumnBytes,
+			wantNilErr: false,
+		},
+		{
+			name: "end before start",
+			result: Result{
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "main.go"},
+							Region:           Region{StartLine: 3, StartColumn: 5, EndColumn: 2},
+						},
+					},
+				},
+			},
+			unit:       ColumnBytes,
+			wantNilErr: false,
+		},
+		{
+			name: "invalid unit",
+			result: Result{
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "main.go"},
+							Region:           Region{StartLine: 3, StartColumn: 5},
+						},
+					},
+				},
+			},
+			unit:       ColumnUnit(7),
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.result.Highlights(fsys, tt.unit)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("highlights mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
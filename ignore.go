@@ -0,0 +1,182 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the conventional name of the file containing the
+// patterns parsed by [ParseIgnore].
+const IgnoreFileName = ".sarifignore"
+
+// IgnoreList is a list of gitignore-style patterns that exclude
+// artifact paths. See [ParseIgnore].
+type IgnoreList struct {
+	patterns []ignorePattern
+}
+
+// ignorePattern is a pattern of an [IgnoreList].
+type ignorePattern struct {
+	// segments contains the slash-separated segments of the
+	// pattern. Unanchored patterns start with a "**" segment.
+	segments []string
+
+	// negate reports whether the pattern re-includes the paths
+	// it matches.
+	negate bool
+
+	// dirOnly reports whether the pattern only matches
+	// directories.
+	dirOnly bool
+}
+
+// ParseIgnore parses the gitignore-style patterns read from r. It
+// supports the syntax described in the documentation of gitignore:
+//
+//   - Blank lines and lines starting with "#" are ignored. A leading
+//     "\" escapes "#" and "!".
+//   - Trailing spaces are ignored.
+//   - A leading "!" negates the pattern, re-including the paths
+//     excluded by a previous pattern. A path cannot be re-included
+//     if one of its parent directories is excluded.
+//   - A trailing "/" makes the pattern only match directories.
+//   - A pattern with a "/" at the beginning or in the middle is
+//     relative to the root. Otherwise, it matches at any level.
+//   - "*", "?" and "[...]" match as in [path.Match]. "**" matches
+//     any number of directories.
+func ParseIgnore(r io.Reader) (IgnoreList, error) {
+	var il IgnoreList
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimRight(s.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var p ignorePattern
+		if rest, found := strings.CutPrefix(line, "!"); found {
+			p.negate = true
+			line = rest
+		} else if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+			line = line[1:]
+		}
+		if rest, found := strings.CutSuffix(line, "/"); found {
+			p.dirOnly = true
+			line = rest
+		}
+
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		if line == "" {
+			continue
+		}
+
+		p.segments = strings.Split(line, "/")
+		for _, seg := range p.segments {
+			if _, err := path.Match(seg, ""); err != nil {
+				return IgnoreList{}, fmt.Errorf("line %v: invalid pattern: %v", n, s.Text())
+			}
+		}
+		if !anchored {
+			p.segments = append([]string{"**"}, p.segments...)
+		}
+		il.patterns = append(il.patterns, p)
+	}
+	if err := s.Err(); err != nil {
+		return IgnoreList{}, fmt.Errorf("read ignore file: %w", err)
+	}
+	return il, nil
+}
+
+// Match reports whether the provided file path is excluded by the
+// ignore list. The path must be relative to the directory of the
+// ignore file.
+func (il IgnoreList) Match(p string) bool {
+	p = path.Clean(filepath.ToSlash(p))
+	if p == "." || p == "/" {
+		return false
+	}
+	segs := strings.Split(strings.TrimPrefix(p, "/"), "/")
+
+	for i := 1; i < len(segs); i++ {
+		if il.match(segs[:i], true) {
+			return true
+		}
+	}
+	return il.match(segs, false)
+}
+
+// match reports whether the path with the provided segments is
+// excluded by the last pattern that matches it.
+func (il IgnoreList) match(segs []string, isDir bool) bool {
+	for i := len(il.patterns) - 1; i >= 0; i-- {
+		p := il.patterns[i]
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if matchSegments(p.segments, segs) {
+			return !p.negate
+		}
+	}
+	return false
+}
+
+// matchSegments reports whether the path segments segs match the
+// pattern segments pattern.
+func matchSegments(pattern, segs []string) bool {
+	if len(pattern) == 0 {
+		return len(segs) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegments(pattern[1:], segs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segs) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segs[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segs[1:])
+}
+
+// ExcludeIgnored returns a copy of the provided [Log] without the
+// results whose first location refers to an artifact excluded by the
+// ignore list. Artifact paths are obtained with
+// [ArtifactLocation.Path], so base URI identifiers are ignored and
+// paths are expected to be relative to the directory of the ignore
+// file. Results without locations are kept.
+func (l Log) ExcludeIgnored(il IgnoreList) Log {
+	runs := make([]Run, len(l.Runs))
+	for i, run := range l.Runs {
+		var results []Result
+		for _, result := range run.Results {
+			ploc := firstPhysicalLocation(result)
+			if ploc.ArtifactLocation.URI != "" {
+				if p, err := ploc.ArtifactLocation.Path(); err == nil && il.Match(p) {
+					continue
+				}
+			}
+			results = append(results, result)
+		}
+		if results == nil && run.Results != nil {
+			results = []Result{}
+		}
+		run.Results = results
+		runs[i] = run
+	}
+	l.Runs = runs
+	return l
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIgnoreList_Match(t *testing.T) {
+	const ignoreFile = `# Generated code.
+*.pb.go
+!keep.pb.go
+
+/vendor/
+testdata/
+docs/**/*.md
+third_party/**
+\#notes
+build   
+`
+
+	il, err := ParseIgnore(strings.NewReader(ignoreFile))
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "api/service.pb.go", want: true},
+		{path: "service.pb.go", want: true},
+		{path: "api/keep.pb.go", want: false},
+		{path: "api/service.go", want: false},
+		{path: "vendor/golang.org/x/text/unicode.go", want: true},
+		{path: "internal/vendor/lib.go", want: false},
+		{path: "pkg/testdata/input.go", want: true},
+		{path: "pkg/testdata", want: false},
+		{path: "docs/guide/intro.md", want: true},
+		{path: "docs/intro.md", want: true},
+		{path: "docs/intro.txt", want: false},
+		{path: "third_party/lib/a.c", want: true},
+		{path: "#notes", want: true},
+		{path: "build", want: true},
+		{path: "build/out/main.go", want: true},
+		{path: "./build/main.go", want: true},
+		{path: "rebuild/main.go", want: false},
+		{path: ".", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := il.Match(tt.path); got != tt.want {
+				t.Errorf("match mismatch: want: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIgnoreList_MatchExcludedParent(t *testing.T) {
+	il, err := ParseIgnore(strings.NewReader("gen/\n!gen/keep.go\n"))
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if !il.Match("gen/keep.go") {
+		t.Errorf("file re-included inside excluded directory")
+	}
+}
+
+func TestParseIgnore(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantNilErr bool
+	}{
+		{
+			name:       "valid",
+			data:       "*.go\n[a-z]*.c\n",
+			wantNilErr: true,
+		},
+		{
+			name:       "invalid pattern",
+			data:       "*.go\n[a-\n",
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseIgnore(strings.NewReader(tt.data))
+			if (err == nil) != tt.wantNilErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestLog_ExcludeIgnored(t *testing.T) {
+	il, err := ParseIgnore(strings.NewReader("vendor/\n"))
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	l := Log{
+		Runs: []Run{
+			{
+				Results: []Result{
+					{
+						RuleID: "R1",
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "vendor/lib.go"},
+								},
+							},
+						},
+					},
+					{
+						RuleID: "R2",
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "main.go"},
+								},
+							},
+						},
+					},
+					{RuleID: "R3"},
+				},
+			},
+			{
+				Results: []Result{
+					{
+						RuleID: "R4",
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "vendor/a/b.go"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	want := Log{
+		Runs: []Run{
+			{
+				Results: []Result{
+					{
+						RuleID: "R2",
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "main.go"},
+								},
+							},
+						},
+					},
+					{RuleID: "R3"},
+				},
+			},
+			{
+				Results: []Result{},
+			},
+		},
+	}
+
+	got := l.ExcludeIgnored(il)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("log mismatch (-want +got):\n%v", diff)
+	}
+	if len(l.Runs[0].Results) != 3 {
+		t.Errorf("input log was modified")
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lint checks.
+const (
+	// LintRuleShortDescription reports rules without short
+	// description.
+	LintRuleShortDescription = "rule-short-description"
+
+	// LintRuleHelpURI reports rules without help URI.
+	LintRuleHelpURI = "rule-help-uri"
+
+	// LintUnusedRule reports rules that are not referenced by any
+	// result of the run.
+	LintUnusedRule = "unused-rule"
+
+	// LintResultLevel reports results without level.
+	LintResultLevel = "result-level"
+
+	// LintMessagePeriod reports result messages that do not end
+	// with a period.
+	LintMessagePeriod = "message-period"
+
+	// LintDuplicateRule reports rules whose identifier is already
+	// declared by a previous rule of the driver. See
+	// [Log.MergeDuplicateRules].
+	LintDuplicateRule = "duplicate-rule"
+)
+
+// LintIssue is a quality issue reported by [Lint].
+type LintIssue struct {
+	// Check is the lint check that reported the issue.
+	Check string
+
+	// Pointer is a JSON pointer to the offending object.
+	Pointer string
+
+	// Message describes the issue.
+	Message string
+}
+
+// String returns the string representation of the lint issue.
+func (issue LintIssue) String() string {
+	return fmt.Sprintf("%v: %v (%v)", issue.Pointer, issue.Message, issue.Check)
+}
+
+// Lint reports quality issues in the provided [Log] that go beyond
+// its validity, so producers can emit SARIF documents that render
+// well in most viewers.
+func Lint(l Log) []LintIssue {
+	var issues []LintIssue
+	for i, run := range l.Runs {
+		used := make(map[string]bool)
+		for j, result := range run.Results {
+			used[run.resultRuleID(result)] = true
+
+			ptr := fmt.Sprintf("/runs/%v/results/%v", i, j)
+			if result.Level == "" {
+				issues = append(issues, LintIssue{
+					Check:   LintResultLevel,
+					Pointer: ptr,
+					Message: "result without level",
+				})
+			}
+			if text := strings.TrimSpace(result.Message.Text); text != "" && !strings.HasSuffix(text, ".") {
+				issues = append(issues, LintIssue{
+					Check:   LintMessagePeriod,
+					Pointer: ptr + "/message/text",
+					Message: "message does not end with a period",
+				})
+			}
+		}
+
+		declared := make(map[string]int)
+		for j, rule := range run.Tool.Driver.Rules {
+			ptr := fmt.Sprintf("/runs/%v/tool/driver/rules/%v", i, j)
+			if k, ok := declared[rule.ID]; ok && rule.ID != "" {
+				issues = append(issues, LintIssue{
+					Check:   LintDuplicateRule,
+					Pointer: ptr,
+					Message: fmt.Sprintf("rule %q is already declared at index %v", rule.ID, k),
+				})
+			} else {
+				declared[rule.ID] = j
+			}
+			if rule.ShortDescription.Text == "" {
+				issues = append(issues, LintIssue{
+					Check:   LintRuleShortDescription,
+					Pointer: ptr,
+					Message: fmt.Sprintf("rule %q without short description", rule.ID),
+				})
+			}
+			if rule.HelpURI == "" {
+				issues = append(issues, LintIssue{
+					Check:   LintRuleHelpURI,
+					Pointer: ptr,
+					Message: fmt.Sprintf("rule %q without help URI", rule.ID),
+				})
+			}
+			if !used[rule.ID] {
+				issues = append(issues, LintIssue{
+					Check:   LintUnusedRule,
+					Pointer: ptr,
+					Message: fmt.Sprintf("rule %q is not used by any result", rule.ID),
+				})
+			}
+		}
+	}
+	return issues
+}
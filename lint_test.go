@@ -0,0 +1,178 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name string
+		l    Log
+		want []LintIssue
+	}{
+		{
+			name: "clean",
+			l: Log{
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{
+								Rules: []Rule{
+									{
+										ID:               "R1",
+										ShortDescription: Description{Text: "Rule 1"},
+										HelpURI:          "https://example.com/R1",
+									},
+								},
+							},
+						},
+						Results: []Result{
+							{
+								RuleID:  "R1",
+								Level:   "error",
+								Message: Description{Text: "Something happened."},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "rule index",
+			l: Log{
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{
+								Rules: []Rule{
+									{
+										ID:               "R1",
+										ShortDescription: Description{Text: "Rule 1"},
+										HelpURI:          "https://example.com/R1",
+									},
+								},
+							},
+						},
+						Results: []Result{
+							{
+								RuleIndex: ptr(0),
+								Level:     "error",
+								Message:   Description{Text: "Something happened."},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "issues",
+			l: Log{
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{
+								Rules: []Rule{
+									{
+										ID:               "R1",
+										ShortDescription: Description{Text: "Rule 1"},
+										HelpURI:          "https://example.com/R1",
+									},
+									{
+										ID: "R2",
+									},
+								},
+							},
+						},
+						Results: []Result{
+							{
+								RuleID:  "R1",
+								Message: Description{Text: "Something happened"},
+							},
+						},
+					},
+				},
+			},
+			want: []LintIssue{
+				{
+					Check:   LintResultLevel,
+					Pointer: "/runs/0/results/0",
+					Message: "result without level",
+				},
+				{
+					Check:   LintMessagePeriod,
+					Pointer: "/runs/0/results/0/message/text",
+					Message: "message does not end with a period",
+				},
+				{
+					Check:   LintRuleShortDescription,
+					Pointer: "/runs/0/tool/driver/rules/1",
+					Message: `rule "R2" without short description`,
+				},
+				{
+					Check:   LintRuleHelpURI,
+					Pointer: "/runs/0/tool/driver/rules/1",
+					Message: `rule "R2" without help URI`,
+				},
+				{
+					Check:   LintUnusedRule,
+					Pointer: "/runs/0/tool/driver/rules/1",
+					Message: `rule "R2" is not used by any result`,
+				},
+			},
+		},
+		{
+			name: "duplicate rule",
+			l: Log{
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{
+								Rules: []Rule{
+									{
+										ID:               "R1",
+										ShortDescription: Description{Text: "Rule 1"},
+										HelpURI:          "https://example.com/R1",
+									},
+									{
+										ID:               "R1",
+										ShortDescription: Description{Text: "Rule 1"},
+										HelpURI:          "https://example.com/R1",
+									},
+								},
+							},
+						},
+						Results: []Result{
+							{
+								RuleID:  "R1",
+								Level:   "error",
+								Message: Description{Text: "Something happened."},
+							},
+						},
+					},
+				},
+			},
+			want: []LintIssue{
+				{
+					Check:   LintDuplicateRule,
+					Pointer: "/runs/0/tool/driver/rules/1",
+					Message: `rule "R1" is already declared at index 0`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := Lint(tt.l)
+			if diff := cmp.Diff(tt.want, issues); diff != "" {
+				t.Errorf("issues mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
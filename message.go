@@ -0,0 +1,196 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ResolveMessage returns the message of the provided result with its
+// placeholders replaced by the message arguments using
+// [FormatMessage]. If the message has no text and no markdown but has
+// an identifier, the message string with that identifier is looked up
+// first in the message strings of the rule that was evaluated to
+// produce the result, as resolved by [Run.ResultRule], and then in the
+// global message strings of the driver. If the message string is not
+// found, the message is returned unchanged.
+func (run Run) ResolveMessage(r Result) Description {
+	msg := r.Message
+	if msg.Text == "" && msg.Markdown == "" && msg.ID != "" {
+		rule, _ := run.ResultRule(r)
+		s, found := rule.MessageStrings[msg.ID]
+		if !found {
+			s, found = run.Tool.Driver.GlobalMessageStrings[msg.ID]
+		}
+		if !found {
+			return msg
+		}
+		msg.Text = s.Text
+		msg.Markdown = s.Markdown
+	}
+	return FormatMessage(msg, msg.Arguments)
+}
+
+// FormatMessage returns a message with the text and markdown of the
+// provided template and their placeholders replaced by the provided
+// arguments. The placeholder "{n}" is replaced by the argument with
+// index n. Placeholders without a corresponding argument are kept.
+// "{{" and "}}" are replaced by literal braces.
+//
+// Arguments are plain text. When they are substituted into the
+// markdown, the characters with a special meaning in Markdown are
+// escaped with a backslash, except within code spans, where they are
+// rendered literally. Code spans whose arguments contain backticks are
+// delimited by longer runs of backticks, so the arguments do not close
+// them. The identifier and the arguments of the template are not
+// copied.
+func FormatMessage(template Description, args []string) Description {
+	return Description{
+		Text:     substituteArguments(template.Text, args, false),
+		Markdown: substituteArguments(template.Markdown, args, true),
+	}
+}
+
+// substituteArguments replaces the placeholders of the provided
+// message string with the corresponding arguments. If markdown is
+// true, the arguments substituted outside code spans are escaped and
+// the code spans are delimited again if their arguments contain
+// backticks.
+func substituteArguments(s string, args []string, markdown bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case markdown && c == '\\' && i+1 < len(s):
+			b.WriteString(s[i : i+2])
+			i++
+		case markdown && c == '`':
+			n := backtickRun(s[i:])
+			end := closingBackticks(s[i+n:], n)
+			if end < 0 {
+				// No closing backticks, so it is not a
+				// code span.
+				b.WriteString(s[i : i+n])
+				i += n - 1
+				break
+			}
+			code := substituteArguments(s[i+n:i+n+end], args, false)
+			b.WriteString(codeSpan(code, n))
+			i += n + end + n - 1
+		case c == '{' && strings.HasPrefix(s[i:], "{{"):
+			b.WriteByte('{')
+			i++
+		case c == '}' && strings.HasPrefix(s[i:], "}}"):
+			b.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				b.WriteByte(c)
+				break
+			}
+			digits := s[i+1 : i+end]
+			if !isDigits(digits) {
+				b.WriteByte(c)
+				break
+			}
+			n, err := strconv.Atoi(digits)
+			if err != nil || n >= len(args) {
+				b.WriteByte(c)
+				break
+			}
+			arg := args[n]
+			if markdown {
+				arg = escapeMarkdown(arg)
+			}
+			b.WriteString(arg)
+			i += end
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// backtickRun returns the number of backticks at the start of s.
+func backtickRun(s string) int {
+	n := 0
+	for n < len(s) && s[n] == '`' {
+		n++
+	}
+	return n
+}
+
+// closingBackticks returns the index of the first run of exactly n
+// backticks in s, which closes a code span opened by n backticks. It
+// returns -1 if there is no such run.
+func closingBackticks(s string, n int) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '`' {
+			continue
+		}
+		run := backtickRun(s[i:])
+		if run == n {
+			return i
+		}
+		i += run - 1
+	}
+	return -1
+}
+
+// codeSpan returns a Markdown code span with the provided content,
+// delimited by n backticks. If the content contains backticks, the
+// code span is delimited by a run of backticks longer than any run in
+// the content, and the content is padded with spaces if it starts or
+// ends with a backtick.
+func codeSpan(code string, n int) string {
+	if !strings.Contains(code, "`") {
+		fence := strings.Repeat("`", n)
+		return fence + code + fence
+	}
+
+	longest := 0
+	for i := 0; i < len(code); i++ {
+		if code[i] == '`' {
+			run := backtickRun(code[i:])
+			longest = max(longest, run)
+			i += run - 1
+		}
+	}
+	fence := strings.Repeat("`", max(n, longest+1))
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+// markdownSpecial contains the characters escaped by
+// [escapeMarkdown].
+const markdownSpecial = "\\`*_{}[]()<>#+-.!|~"
+
+// escapeMarkdown escapes the characters of s that have a special
+// meaning in Markdown.
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		if strings.ContainsRune(markdownSpecial, c) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// isDigits reports whether s is a non-empty string of decimal digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun_ResolveMessage(t *testing.T) {
+	run := Run{
+		Tool: Tool{
+			Driver: Driver{
+				Rules: []Rule{
+					{
+						ID: "R1",
+						MessageStrings: map[string]Description{
+							"default": {
+								Text:     "Variable {0} is unused in {1}.",
+								Markdown: "Variable `{0}` is unused in {1}.",
+							},
+							"textOnly": {Text: "Use {{braces}} for {0}."},
+						},
+					},
+				},
+				GlobalMessageStrings: map[string]Description{
+					"default": {Text: "Global default."},
+					"global":  {Text: "Shared message for {0}."},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ruleID  string
+		message Description
+		want    Description
+	}{
+		{
+			name:    "message string",
+			ruleID:  "R1",
+			message: Description{ID: "default", Arguments: []string{"x", "main"}},
+			want: Description{
+				Text:     "Variable x is unused in main.",
+				Markdown: "Variable `x` is unused in main.",
+			},
+		},
+		{
+			name:    "escaped braces",
+			ruleID:  "R1",
+			message: Description{ID: "textOnly", Arguments: []string{"blocks"}},
+			want:    Description{Text: "Use {braces} for blocks."},
+		},
+		{
+			name:    "missing argument",
+			ruleID:  "R1",
+			message: Description{ID: "default", Arguments: []string{"x"}},
+			want: Description{
+				Text:     "Variable x is unused in {1}.",
+				Markdown: "Variable `x` is unused in {1}.",
+			},
+		},
+		{
+			name:    "text takes precedence",
+			ruleID:  "R1",
+			message: Description{Text: "Unused {0}.", ID: "default", Arguments: []string{"y"}},
+			want:    Description{Text: "Unused y."},
+		},
+		{
+			name:    "not a placeholder",
+			ruleID:  "R1",
+			message: Description{Text: "Map {a} and {-1} and {0", Arguments: []string{"x"}},
+			want:    Description{Text: "Map {a} and {-1} and {0"},
+		},
+		{
+			name:    "global message string",
+			ruleID:  "R1",
+			message: Description{ID: "global", Arguments: []string{"x"}},
+			want:    Description{Text: "Shared message for x."},
+		},
+		{
+			name:    "undeclared rule",
+			ruleID:  "R2",
+			message: Description{ID: "default"},
+			want:    Description{Text: "Global default."},
+		},
+		{
+			name:    "unknown message string",
+			ruleID:  "R1",
+			message: Description{ID: "unknown", Arguments: []string{"x"}},
+			want:    Description{ID: "unknown", Arguments: []string{"x"}},
+		},
+		{
+			name:    "plain text",
+			ruleID:  "R1",
+			message: Description{Text: "Unused variable."},
+			want:    Description{Text: "Unused variable."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := run.ResolveMessage(Result{RuleID: tt.ruleID, Message: tt.message})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		template Description
+		args     []string
+		want     Description
+	}{
+		{
+			name: "text and markdown",
+			template: Description{
+				Text:     "Call to {0} in {1}.",
+				Markdown: "Call to **{0}** in {1}.",
+			},
+			args: []string{"os.Exit", "main"},
+			want: Description{
+				Text:     "Call to os.Exit in main.",
+				Markdown: "Call to **os\\.Exit** in main.",
+			},
+		},
+		{
+			name: "markdown escaping",
+			template: Description{
+				Text:     "Value {0}.",
+				Markdown: "Value {0}.",
+			},
+			args: []string{"*a_b* [x](y) <z> \\"},
+			want: Description{
+				Text:     "Value *a_b* [x](y) <z> \\.",
+				Markdown: "Value \\*a\\_b\\* \\[x\\]\\(y\\) \\<z\\> \\\\.",
+			},
+		},
+		{
+			name:     "code span",
+			template: Description{Markdown: "Use `{0}` instead of {0}."},
+			args:     []string{"a*b"},
+			want:     Description{Markdown: "Use `a*b` instead of a\\*b."},
+		},
+		{
+			name:     "backticks in code span",
+			template: Description{Markdown: "Call `{0}` now"},
+			args:     []string{"a`b*c"},
+			want:     Description{Markdown: "Call ``a`b*c`` now"},
+		},
+		{
+			name:     "leading backtick in code span",
+			template: Description{Markdown: "Call `{0}` now"},
+			args:     []string{"``a"},
+			want:     Description{Markdown: "Call ``` ``a ``` now"},
+		},
+		{
+			name:     "unclosed backtick",
+			template: Description{Markdown: "A ` then {0}."},
+			args:     []string{"a*b"},
+			want:     Description{Markdown: "A ` then a\\*b."},
+		},
+		{
+			name:     "escaped backtick",
+			template: Description{Markdown: "A \\` then {0}."},
+			args:     []string{"a*b"},
+			want:     Description{Markdown: "A \\` then a\\*b."},
+		},
+		{
+			name:     "braces and missing arguments",
+			template: Description{Text: "{{{0}}} {1}", Markdown: "{{{0}}} {1}"},
+			args:     []string{"x"},
+			want:     Description{Text: "{x} {1}", Markdown: "{x} {1}"},
+		},
+		{
+			name:     "identifier not copied",
+			template: Description{Text: "Found {0}.", ID: "default", Arguments: []string{"y"}},
+			args:     []string{"x"},
+			want:     Description{Text: "Found x."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatMessage(tt.template, tt.args)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
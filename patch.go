@@ -0,0 +1,155 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// patchOperation is a [JSON Patch] operation.
+//
+// [JSON Patch]: https://www.rfc-editor.org/rfc/rfc6902
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyPatch applies the provided [JSON Patch] document to the JSON
+// representation of the [Log] and returns the resulting [Log]. The
+// patch is applied atomically, so the returned [Log] is only valid
+// if the error is nil. The resulting document is decoded as if
+// [WithStrict] was used, so it must have the supported SARIF version
+// and must not contain fields unknown to this package.
+//
+// [JSON Patch]: https://www.rfc-editor.org/rfc/rfc6902
+func ApplyPatch(l Log, patch []byte) (Log, error) {
+	var ops []patchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return Log{}, fmt.Errorf("decode JSON patch: %w", err)
+	}
+
+	doc, err := toDocument(l)
+	if err != nil {
+		return Log{}, err
+	}
+	for i, op := range ops {
+		if doc, err = op.apply(doc); err != nil {
+			return Log{}, fmt.Errorf("JSON patch operation %v (%v): %w", i, op.Op, err)
+		}
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return Log{}, fmt.Errorf("marshal JSON document: %w", err)
+	}
+	return Decode(bytes.NewReader(b), WithStrict())
+}
+
+// apply applies the patch operation to the provided document and
+// returns the resulting document.
+func (op patchOperation) apply(doc any) (any, error) {
+	if op.Path == nil {
+		return nil, errors.New("missing path")
+	}
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		v, err := rawToDocument(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return path.add(doc, v)
+		case "replace":
+			if len(path) == 0 {
+				return v, nil
+			}
+			if doc, _, err = path.remove(doc); err != nil {
+				return nil, err
+			}
+			return path.add(doc, v)
+		default:
+			cur, err := path.get(doc)
+			if err != nil {
+				return nil, err
+			}
+			if !jsonEqual(cur, v) {
+				return nil, fmt.Errorf("test failed: %v", path)
+			}
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err = path.remove(doc)
+		return doc, err
+	case "move", "copy":
+		if op.From == nil {
+			return nil, errors.New("missing from")
+		}
+		from, err := parsePointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		var v any
+		if op.Op == "move" {
+			if len(path) > len(from) && slices.Equal(from, path[:len(from)]) {
+				return nil, fmt.Errorf("cannot move %v into one of its children", from)
+			}
+			if doc, v, err = from.remove(doc); err != nil {
+				return nil, err
+			}
+		} else {
+			if v, err = from.get(doc); err != nil {
+				return nil, err
+			}
+			if v, err = toDocument(v); err != nil {
+				return nil, err
+			}
+		}
+		return path.add(doc, v)
+	}
+	return nil, fmt.Errorf("unknown operation %q", op.Op)
+}
+
+// rawToDocument returns the generic representation of the provided
+// JSON value.
+func rawToDocument(raw json.RawMessage) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decode JSON value: %w", err)
+	}
+	return v, nil
+}
+
+// jsonEqual reports whether the generic JSON values a and b are
+// equal. Numbers are compared by value.
+func jsonEqual(a, b any) bool {
+	normalize := func(v any) any {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		var n any
+		if err := json.Unmarshal(data, &n); err != nil {
+			return nil
+		}
+		return n
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		patch      string
+		want       Log
+		wantNilErr bool
+	}{
+		{
+			name:  "add",
+			patch: `[{"op": "add", "path": "/runs/0/results/0", "value": {"ruleId": "R0"}}]`,
+			want: Log{
+				Version: "2.1.0",
+				Runs:    []Run{{Results: []Result{{RuleID: "R0"}, {RuleID: "R1", Level: "warning"}, {RuleID: "R2"}}}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:  "remove",
+			patch: `[{"op": "remove", "path": "/runs/0/results/1"}]`,
+			want: Log{
+				Version: "2.1.0",
+				Runs:    []Run{{Results: []Result{{RuleID: "R1", Level: "warning"}}}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:  "replace",
+			patch: `[{"op": "replace", "path": "/runs/0/results/0/level", "value": "error"}]`,
+			want: Log{
+				Version: "2.1.0",
+				Runs:    []Run{{Results: []Result{{RuleID: "R1", Level: "error"}, {RuleID: "R2"}}}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:  "move",
+			patch: `[{"op": "move", "from": "/runs/0/results/0/level", "path": "/runs/0/results/1/level"}]`,
+			want: Log{
+				Version: "2.1.0",
+				Runs:    []Run{{Results: []Result{{RuleID: "R1"}, {RuleID: "R2", Level: "warning"}}}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:  "copy",
+			patch: `[{"op": "copy", "from": "/runs/0/results/0", "path": "/runs/0/results/-"}]`,
+			want: Log{
+				Version: "2.1.0",
+				Runs:    []Run{{Results: []Result{{RuleID: "R1", Level: "warning"}, {RuleID: "R2"}, {RuleID: "R1", Level: "warning"}}}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "test",
+			patch: `[
+				{"op": "test", "path": "/runs/0/results/0/ruleId", "value": "R1"},
+				{"op": "replace", "path": "/runs/0/results/0/level", "value": "note"}
+			]`,
+			want: Log{
+				Version: "2.1.0",
+				Runs:    []Run{{Results: []Result{{RuleID: "R1", Level: "note"}, {RuleID: "R2"}}}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:  "replace root",
+			patch: `[{"op": "replace", "path": "", "value": {"version": "2.1.0", "runs": [{"results": [{"ruleId": "R3"}]}]}}]`,
+			want: Log{
+				Version: "2.1.0",
+				Runs:    []Run{{Results: []Result{{RuleID: "R3"}}}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:       "unknown field",
+			patch:      `[{"op": "add", "path": "/runs/0/results/0/rule_id", "value": "R3"}]`,
+			wantNilErr: false,
+		},
+		{
+			name:       "test failed",
+			patch:      `[{"op": "test", "path": "/runs/0/results/0/ruleId", "value": "R2"}]`,
+			wantNilErr: false,
+		},
+		{
+			name:       "replace missing",
+			patch:      `[{"op": "replace", "path": "/runs/0/foo", "value": 1}]`,
+			wantNilErr: false,
+		},
+		{
+			name:       "move into child",
+			patch:      `[{"op": "move", "from": "/runs/0", "path": "/runs/0/results"}]`,
+			wantNilErr: false,
+		},
+		{
+			name:       "missing value",
+			patch:      `[{"op": "add", "path": "/runs/0/results/-"}]`,
+			wantNilErr: false,
+		},
+		{
+			name:       "unknown operation",
+			patch:      `[{"op": "foo", "path": "/runs"}]`,
+			wantNilErr: false,
+		},
+		{
+			name:       "invalid version",
+			patch:      `[{"op": "replace", "path": "/version", "value": "3.1.0"}]`,
+			wantNilErr: false,
+		},
+		{
+			name:       "malformed",
+			patch:      `{"op": "remove", "path": "/runs"}`,
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Log{
+				Version: "2.1.0",
+				Runs:    []Run{{Results: []Result{{RuleID: "R1", Level: "warning"}, {RuleID: "R2"}}}},
+			}
+
+			got, err := ApplyPatch(l, []byte(tt.patch))
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("log mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
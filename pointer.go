@@ -0,0 +1,305 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GetPointer returns the value referenced by the provided [JSON
+// Pointer] in the JSON representation of the [Log]. The returned
+// value is one of the types produced by decoding JSON into an
+// interface value, with numbers represented as [json.Number].
+//
+// [JSON Pointer]: https://www.rfc-editor.org/rfc/rfc6901
+func GetPointer(l Log, ptr string) (any, error) {
+	p, err := parsePointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := toDocument(l)
+	if err != nil {
+		return nil, err
+	}
+	return p.get(doc)
+}
+
+// SetPointer sets the value referenced by the provided [JSON Pointer]
+// in the JSON representation of the [Log]. If the pointer references
+// a member of an object, the member is added if it does not exist.
+// If the pointer references an array element, the element must exist,
+// unless the last reference token is "-", in which case the value is
+// appended to the array. The resulting document is decoded as if
+// [WithStrict] was used, so setting a member unknown to this package
+// returns an error.
+//
+// [JSON Pointer]: https://www.rfc-editor.org/rfc/rfc6901
+func SetPointer(l *Log, ptr string, value any) error {
+	p, err := parsePointer(ptr)
+	if err != nil {
+		return err
+	}
+	doc, err := toDocument(*l)
+	if err != nil {
+		return err
+	}
+	v, err := toDocument(value)
+	if err != nil {
+		return err
+	}
+	if doc, err = p.set(doc, v); err != nil {
+		return err
+	}
+	nl, err := fromDocument(doc)
+	if err != nil {
+		return err
+	}
+	*l = nl
+	return nil
+}
+
+// errPointerNotFound is returned when a JSON pointer references a
+// non-existent value.
+var errPointerNotFound = errors.New("JSON pointer not found")
+
+// jsonPointer is a parsed JSON pointer. Every element is an unescaped
+// reference token.
+type jsonPointer []string
+
+// parsePointer parses the provided JSON pointer.
+func parsePointer(s string) (jsonPointer, error) {
+	if s == "" {
+		return jsonPointer{}, nil
+	}
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer: %q", s)
+	}
+
+	var p jsonPointer
+	for _, tok := range strings.Split(s[1:], "/") {
+		for i := 0; i < len(tok); i++ {
+			if tok[i] == '~' && (i+1 >= len(tok) || (tok[i+1] != '0' && tok[i+1] != '1')) {
+				return nil, fmt.Errorf("invalid JSON pointer: %q", s)
+			}
+		}
+		tok = strings.ReplaceAll(tok, "~1", "/")
+		tok = strings.ReplaceAll(tok, "~0", "~")
+		p = append(p, tok)
+	}
+	return p, nil
+}
+
+// String returns the string representation of the JSON pointer.
+func (p jsonPointer) String() string {
+	var sb strings.Builder
+	for _, tok := range p {
+		tok = strings.ReplaceAll(tok, "~", "~0")
+		tok = strings.ReplaceAll(tok, "/", "~1")
+		sb.WriteString("/" + tok)
+	}
+	return sb.String()
+}
+
+// get returns the value referenced by the JSON pointer in the
+// provided document.
+func (p jsonPointer) get(doc any) (any, error) {
+	v := doc
+	for _, tok := range p {
+		switch node := v.(type) {
+		case map[string]any:
+			child, ok := node[tok]
+			if !ok {
+				return nil, fmt.Errorf("%w: %v", errPointerNotFound, p)
+			}
+			v = child
+		case []any:
+			i, err := arrayIndex(tok, len(node))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v: %w", errPointerNotFound, p, err)
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("%w: %v", errPointerNotFound, p)
+		}
+	}
+	return v, nil
+}
+
+// set sets the value referenced by the JSON pointer in the provided
+// document and returns the resulting document.
+func (p jsonPointer) set(doc, v any) (any, error) {
+	if len(p) == 0 {
+		return v, nil
+	}
+	return p.update(doc, func(parent any, tok string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[tok] = v
+			return node, nil
+		case []any:
+			if tok == "-" {
+				return append(node, v), nil
+			}
+			i, err := arrayIndex(tok, len(node))
+			if err != nil {
+				return nil, err
+			}
+			node[i] = v
+			return node, nil
+		}
+		return nil, errors.New("not a container")
+	})
+}
+
+// add adds the value to the location referenced by the JSON pointer
+// following the semantics of the JSON Patch "add" operation, and
+// returns the resulting document.
+func (p jsonPointer) add(doc, v any) (any, error) {
+	if len(p) == 0 {
+		return v, nil
+	}
+	return p.update(doc, func(parent any, tok string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[tok] = v
+			return node, nil
+		case []any:
+			if tok == "-" {
+				return append(node, v), nil
+			}
+			i, err := arrayIndex(tok, len(node)+1)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Insert(node, i, v), nil
+		}
+		return nil, errors.New("not a container")
+	})
+}
+
+// remove removes the value referenced by the JSON pointer. It returns
+// the resulting document and the removed value.
+func (p jsonPointer) remove(doc any) (any, any, error) {
+	if len(p) == 0 {
+		return nil, nil, errors.New("cannot remove the root of the document")
+	}
+	var removed any
+	doc, err := p.update(doc, func(parent any, tok string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			v, ok := node[tok]
+			if !ok {
+				return nil, fmt.Errorf("missing member %q", tok)
+			}
+			removed = v
+			delete(node, tok)
+			return node, nil
+		case []any:
+			i, err := arrayIndex(tok, len(node))
+			if err != nil {
+				return nil, err
+			}
+			removed = node[i]
+			return slices.Delete(node, i, i+1), nil
+		}
+		return nil, errors.New("not a container")
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc, removed, nil
+}
+
+// update calls fn with the container referenced by all but the last
+// token of the JSON pointer and the last token. The container
+// returned by fn replaces the original one in the document. The JSON
+// pointer must not reference the root of the document.
+func (p jsonPointer) update(doc any, fn func(parent any, tok string) (any, error)) (any, error) {
+	v, err := updateNode(doc, p, fn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v: %w", errPointerNotFound, p, err)
+	}
+	return v, nil
+}
+
+// updateNode is a helper of [jsonPointer.update] that walks the
+// document recursively.
+func updateNode(node any, toks []string, fn func(parent any, tok string) (any, error)) (any, error) {
+	if len(toks) == 1 {
+		return fn(node, toks[0])
+	}
+
+	tok := toks[0]
+	switch node := node.(type) {
+	case map[string]any:
+		child, ok := node[tok]
+		if !ok {
+			return nil, fmt.Errorf("missing member %q", tok)
+		}
+		child, err := updateNode(child, toks[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[tok] = child
+		return node, nil
+	case []any:
+		i, err := arrayIndex(tok, len(node))
+		if err != nil {
+			return nil, err
+		}
+		child, err := updateNode(node[i], toks[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = child
+		return node, nil
+	}
+	return nil, errors.New("not a container")
+}
+
+// arrayIndex parses an array index reference token and checks that
+// it is in the range [0, n).
+func arrayIndex(tok string, n int) (int, error) {
+	if tok == "" || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	i, err := strconv.Atoi(tok)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	if i >= n {
+		return 0, fmt.Errorf("array index out of range: %v", i)
+	}
+	return i, nil
+}
+
+// toDocument returns the generic JSON representation of v.
+func toDocument(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal JSON document: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON document: %w", err)
+	}
+	return doc, nil
+}
+
+// fromDocument returns the [Log] represented by the generic JSON
+// document doc. The document is decoded as if [WithStrict] was used.
+func fromDocument(doc any) (Log, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return Log{}, fmt.Errorf("marshal JSON document: %w", err)
+	}
+	return Decode(bytes.NewReader(b), WithStrict())
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetPointer(t *testing.T) {
+	l, err := DecodeFile("testdata/govulncheck.json")
+	if err != nil {
+		t.Fatalf("could not decode SARIF file: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		ptr        string
+		want       any
+		wantNilErr bool
+	}{
+		{
+			name:       "string",
+			ptr:        "/runs/0/results/0/ruleId",
+			want:       "GO-2021-0113",
+			wantNilErr: true,
+		},
+		{
+			name:       "number",
+			ptr:        "/runs/0/results/0/locations/0/physicalLocation/region/startLine",
+			want:       json.Number("1"),
+			wantNilErr: true,
+		},
+		{
+			name:       "schema",
+			ptr:        "/$schema",
+			want:       l.Schema,
+			wantNilErr: true,
+		},
+		{
+			name:       "missing member",
+			ptr:        "/runs/0/foo",
+			wantNilErr: false,
+		},
+		{
+			name:       "out of range",
+			ptr:        "/runs/1",
+			wantNilErr: false,
+		},
+		{
+			name:       "leading zero",
+			ptr:        "/runs/00",
+			wantNilErr: false,
+		},
+		{
+			name:       "invalid pointer",
+			ptr:        "runs",
+			wantNilErr: false,
+		},
+		{
+			name:       "invalid escape",
+			ptr:        "/runs~2",
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := GetPointer(l, tt.ptr)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if diff := cmp.Diff(tt.want, v); diff != "" {
+				t.Errorf("value mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestSetPointer(t *testing.T) {
+	tests := []struct {
+		name       string
+		ptr        string
+		value      any
+		want       Log
+		wantNilErr bool
+	}{
+		{
+			name:  "replace",
+			ptr:   "/runs/0/results/0/level",
+			value: "error",
+			want: Log{
+				Version: "2.1.0",
+				Runs: []Run{{
+					Tool:    Tool{Driver: Driver{Properties: map[string]any{"x": "y"}}},
+					Results: []Result{{RuleID: "R1", Level: "error"}},
+				}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:  "append",
+			ptr:   "/runs/0/results/-",
+			value: Result{RuleID: "R2"},
+			want: Log{
+				Version: "2.1.0",
+				Runs: []Run{{
+					Tool:    Tool{Driver: Driver{Properties: map[string]any{"x": "y"}}},
+					Results: []Result{{RuleID: "R1", Level: "warning"}, {RuleID: "R2"}},
+				}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:  "escaped",
+			ptr:   "/runs/0/tool/driver/properties/a~1b",
+			value: 1,
+			want: Log{
+				Version: "2.1.0",
+				Runs: []Run{{
+					Tool:    Tool{Driver: Driver{Properties: map[string]any{"x": "y", "a/b": float64(1)}}},
+					Results: []Result{{RuleID: "R1", Level: "warning"}},
+				}},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:       "out of range",
+			ptr:        "/runs/0/results/1",
+			value:      Result{},
+			wantNilErr: false,
+		},
+		{
+			name:       "missing parent",
+			ptr:        "/runs/0/foo/bar",
+			value:      "x",
+			wantNilErr: false,
+		},
+		{
+			name:       "unknown member",
+			ptr:        "/runs/0/bogus",
+			value:      "x",
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Log{
+				Version: "2.1.0",
+				Runs: []Run{{
+					Tool:    Tool{Driver: Driver{Properties: map[string]any{"x": "y"}}},
+					Results: []Result{{RuleID: "R1", Level: "warning"}},
+				}},
+			}
+
+			if err := SetPointer(&l, tt.ptr, tt.value); err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if diff := cmp.Diff(tt.want, l); diff != "" {
+				t.Errorf("log mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"cmp"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ResultSortKey specifies the order of the results returned by
+// [Run.ResultsPage].
+type ResultSortKey int
+
+// Supported result sort keys.
+const (
+	// SortByIndex keeps the order in which results appear in
+	// the run.
+	SortByIndex ResultSortKey = iota
+
+	// SortByRuleID sorts results by rule identifier.
+	SortByRuleID
+
+	// SortByLevel sorts results by level, from "error" to
+	// "none".
+	SortByLevel
+
+	// SortByLocation sorts results using [CompareResults].
+	SortByLocation
+)
+
+// ResultsPage returns at most limit results of the run, starting at
+// offset, after sorting them by the provided key. If limit is zero or
+// negative, all the results starting at offset are returned. Sorting
+// is stable, so results that are equal according to the sort key keep
+// the order in which they appear in the run. The returned slice does
+// not share memory with the run.
+func (run Run) ResultsPage(offset, limit int, key ResultSortKey) []Result {
+	if offset < 0 || offset >= len(run.Results) {
+		return nil
+	}
+
+	results := slices.Clone(run.Results)
+	switch key {
+	case SortByRuleID:
+		slices.SortStableFunc(results, func(a, b Result) int {
+			return cmp.Compare(a.RuleID, b.RuleID)
+		})
+	case SortByLevel:
+		slices.SortStableFunc(results, func(a, b Result) int {
+			return cmp.Compare(levelRank(a.Level), levelRank(b.Level))
+		})
+	case SortByLocation:
+		slices.SortStableFunc(results, CompareResults)
+	}
+
+	end := len(results)
+	if limit > 0 {
+		end = min(offset+limit, end)
+	}
+	return results[offset:end]
+}
+
+// CompareResults compares two results by the artifact, start line
+// and start column of their first physical location, then by rule
+// identifier and finally by message text. It returns -1 if a sorts
+// before b, +1 if a sorts after b and 0 if they are equal. It is
+// meant to be used with [slices.SortFunc] and similar functions to
+// obtain a stable ordering of results.
+func CompareResults(a, b Result) int {
+	if c := comparePhysicalLocations(firstPhysicalLocation(a), firstPhysicalLocation(b)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.RuleID, b.RuleID); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Message.Text, b.Message.Text)
+}
+
+// RuleUsage reports how a rule is used by the results of a [Log].
+type RuleUsage struct {
+	// RuleID is the rule identifier.
+	RuleID string
+
+	// Declared reports whether the rule is declared by the
+	// driver of any run.
+	Declared bool
+
+	// Results is the number of results that reference the rule.
+	Results int
+}
+
+// RuleUsage returns, for every rule, the number of results of the log
+// that reference it. Rules that are declared but never fired have
+// zero results, and rules that are referenced by results but not
+// declared are reported as not declared. Declared rules are returned
+// first, in declaration order, followed by undeclared rules in the
+// order in which they are first referenced.
+func (l Log) RuleUsage() []RuleUsage {
+	var usage []RuleUsage
+	idxs := make(map[string]int)
+	get := func(id string) *RuleUsage {
+		i, ok := idxs[id]
+		if !ok {
+			i = len(usage)
+			idxs[id] = i
+			usage = append(usage, RuleUsage{RuleID: id})
+		}
+		return &usage[i]
+	}
+
+	for _, run := range l.Runs {
+		for _, rule := range run.Tool.Driver.Rules {
+			get(rule.ID).Declared = true
+		}
+	}
+	for _, run := range l.Runs {
+		for _, result := range run.Results {
+			id := run.resultRuleID(result)
+			if id == "" {
+				continue
+			}
+			get(id).Results++
+		}
+	}
+	return usage
+}
+
+// ComponentSummary reports the results of a [Log] that belong to a
+// component, such as a Go module or a directory subtree.
+type ComponentSummary struct {
+	// Prefix is the path prefix that identifies the component.
+	Prefix string
+
+	// Errors is the number of results with level "error".
+	Errors int
+
+	// Warnings is the number of results with level "warning" or
+	// without level.
+	Warnings int
+
+	// Notes is the number of results with level "note".
+	Notes int
+
+	// Others is the number of results with any other level.
+	Others int
+}
+
+// SummarizeComponents groups the results of the log by the component
+// they belong to and counts them by level. Components are identified
+// by slash-separated path prefixes, such as the directories of the Go
+// modules of a repository. A result belongs to the component with the
+// longest prefix that contains the path of its first location, as
+// returned by [ArtifactLocation.Path]. Prefixes match whole path
+// segments, so "a/b" contains "a/b/c.go" but not "a/bc.go". An empty
+// prefix matches every path. The returned summaries are in the same
+// order as prefixes, followed by a summary with prefix "(other)" if
+// some results do not belong to any component.
+func (l Log) SummarizeComponents(prefixes []string) []ComponentSummary {
+	summaries := make([]ComponentSummary, len(prefixes))
+	for i, prefix := range prefixes {
+		summaries[i].Prefix = prefix
+	}
+	other := ComponentSummary{Prefix: "(other)"}
+
+	for _, run := range l.Runs {
+		for _, result := range run.Results {
+			p, _ := firstPhysicalLocation(result).ArtifactLocation.Path()
+			p = filepath.ToSlash(p)
+
+			summary := &other
+			best := -1
+			for i, prefix := range prefixes {
+				prefix = strings.TrimSuffix(prefix, "/")
+				if len(prefix) <= best || !hasPathPrefix(p, prefix) {
+					continue
+				}
+				summary = &summaries[i]
+				best = len(prefix)
+			}
+
+			switch result.Level {
+			case "error":
+				summary.Errors++
+			case "warning", "":
+				summary.Warnings++
+			case "note":
+				summary.Notes++
+			default:
+				summary.Others++
+			}
+		}
+	}
+
+	if other != (ComponentSummary{Prefix: other.Prefix}) {
+		summaries = append(summaries, other)
+	}
+	return summaries
+}
+
+// hasPathPrefix reports whether the slash-separated path p is prefix
+// or is contained in the directory prefix.
+func hasPathPrefix(p, prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	rest, found := strings.CutPrefix(p, prefix)
+	return found && (rest == "" || strings.HasPrefix(rest, "/"))
+}
+
+// levelRank returns the rank of the provided level. Lower ranks are
+// more severe. Results without level are considered warnings.
+func levelRank(level string) int {
+	switch level {
+	case "error":
+		return 0
+	case "warning", "":
+		return 1
+	case "note":
+		return 2
+	case "none":
+		return 3
+	}
+	return 4
+}
+
+// firstPhysicalLocation returns the physical location of the first
+// location of the result.
+func firstPhysicalLocation(r Result) PhysicalLocation {
+	if len(r.Locations) == 0 {
+		return PhysicalLocation{}
+	}
+	return r.Locations[0].PhysicalLocation
+}
+
+// comparePhysicalLocations compares two physical locations by
+// artifact location, start line and start column.
+func comparePhysicalLocations(a, b PhysicalLocation) int {
+	if c := cmp.Compare(a.ArtifactLocation.URIBaseID, b.ArtifactLocation.URIBaseID); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.ArtifactLocation.URI, b.ArtifactLocation.URI); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Region.StartLine, b.Region.StartLine); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Region.StartColumn, b.Region.StartColumn)
+}
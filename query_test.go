@@ -0,0 +1,491 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun_ResultsPage(t *testing.T) {
+	run := Run{
+		Results: []Result{
+			{
+				RuleID: "R3",
+				Level:  "note",
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "b.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+				},
+			},
+			{
+				RuleID: "R1",
+				Level:  "warning",
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10},
+						},
+					},
+				},
+			},
+			{
+				RuleID: "R2",
+				Level:  "error",
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 2},
+						},
+					},
+				},
+			},
+			{
+				RuleID: "R1",
+				Level:  "error",
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "c.go"},
+							Region:           Region{StartLine: 1},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		offset  int
+		limit   int
+		key     ResultSortKey
+		wantIDs []string
+	}{
+		{
+			name:    "index",
+			offset:  0,
+			limit:   2,
+			key:     SortByIndex,
+			wantIDs: []string{"R3", "R1"},
+		},
+		{
+			name:    "rule id",
+			offset:  1,
+			limit:   2,
+			key:     SortByRuleID,
+			wantIDs: []string{"R1", "R2"},
+		},
+		{
+			name:    "level",
+			offset:  0,
+			limit:   3,
+			key:     SortByLevel,
+			wantIDs: []string{"R2", "R1", "R1"},
+		},
+		{
+			name:    "location",
+			offset:  0,
+			limit:   0,
+			key:     SortByLocation,
+			wantIDs: []string{"R2", "R1", "R3", "R1"},
+		},
+		{
+			name:    "limit past end",
+			offset:  3,
+			limit:   10,
+			key:     SortByIndex,
+			wantIDs: []string{"R1"},
+		},
+		{
+			name:    "offset past end",
+			offset:  4,
+			limit:   10,
+			key:     SortByIndex,
+			wantIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for _, r := range run.ResultsPage(tt.offset, tt.limit, tt.key) {
+				ids = append(ids, r.RuleID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, ids); diff != "" {
+				t.Errorf("rule IDs mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+
+	if run.Results[0].RuleID != "R3" {
+		t.Errorf("run results were modified")
+	}
+}
+
+func TestCompareResults(t *testing.T) {
+	tests := []struct {
+		name string
+		a    Result
+		b    Result
+		want int
+	}{
+		{
+			name: "uri",
+			a: Result{
+				RuleID:  "R2",
+				Message: Description{Text: "b"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 1},
+						},
+					},
+				},
+			},
+			b: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "b.go"},
+							Region:           Region{StartLine: 1, StartColumn: 1},
+						},
+					},
+				},
+			},
+			want: -1,
+		},
+		{
+			name: "start line",
+			a: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 1},
+						},
+					},
+				},
+			},
+			b: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 9, StartColumn: 5},
+						},
+					},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "start column",
+			a: Result{
+				RuleID:  "R2",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 1},
+						},
+					},
+				},
+			},
+			b: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 2},
+						},
+					},
+				},
+			},
+			want: -1,
+		},
+		{
+			name: "rule id",
+			a: Result{
+				RuleID:  "R2",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 1},
+						},
+					},
+				},
+			},
+			b: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "b"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 1},
+						},
+					},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "message",
+			a: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 1},
+						},
+					},
+				},
+			},
+			b: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "b"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 1},
+						},
+					},
+				},
+			},
+			want: -1,
+		},
+		{
+			name: "equal",
+			a: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 1},
+						},
+					},
+				},
+			},
+			b: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 10, StartColumn: 1},
+						},
+					},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "no location",
+			a:    Result{RuleID: "R1"},
+			b: Result{
+				RuleID:  "R1",
+				Message: Description{Text: "a"},
+				Locations: []Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 1, StartColumn: 1},
+						},
+					},
+				},
+			},
+			want: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareResults(tt.a, tt.b); got != tt.want {
+				t.Errorf("comparison mismatch: want: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLog_RuleUsage(t *testing.T) {
+	l := Log{
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Rules: []Rule{{ID: "R1"}, {ID: "R2"}},
+					},
+				},
+				Results: []Result{
+					{RuleID: "R1"},
+					{RuleID: "R3"},
+					{RuleID: "R1"},
+					{},
+				},
+			},
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Rules: []Rule{{ID: "R1"}, {ID: "R4"}},
+					},
+				},
+				Results: []Result{
+					{RuleID: "R1"},
+					{RuleIndex: ptr(1)},
+				},
+			},
+		},
+	}
+
+	want := []RuleUsage{
+		{RuleID: "R1", Declared: true, Results: 3},
+		{RuleID: "R2", Declared: true, Results: 0},
+		{RuleID: "R4", Declared: true, Results: 1},
+		{RuleID: "R3", Declared: false, Results: 1},
+	}
+	if diff := cmp.Diff(want, l.RuleUsage()); diff != "" {
+		t.Errorf("usage mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestLog_SummarizeComponents(t *testing.T) {
+	l := Log{
+		Runs: []Run{
+			{
+				Results: []Result{
+					{
+						Level: "error",
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "svc/api/main.go"},
+								},
+							},
+						},
+					},
+					{
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "svc/api/internal/db.go"},
+								},
+							},
+						},
+					},
+					{
+						Level: "note",
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "svc/apigw/main.go"},
+								},
+							},
+						},
+					},
+					{
+						Level: "warning",
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "lib/log.go"},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Results: []Result{
+					{
+						Level: "none",
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "svc/main.go"},
+								},
+							},
+						},
+					},
+					{
+						Level: "note",
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "README.md"},
+								},
+							},
+						},
+					},
+					{Level: "error"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		prefixes []string
+		want     []ComponentSummary
+	}{
+		{
+			name:     "nested prefixes",
+			prefixes: []string{"svc/api/", "svc", "lib"},
+			want: []ComponentSummary{
+				{Prefix: "svc/api/", Errors: 1, Warnings: 1},
+				{Prefix: "svc", Notes: 1, Others: 1},
+				{Prefix: "lib", Warnings: 1},
+				{Prefix: "(other)", Errors: 1, Notes: 1},
+			},
+		},
+		{
+			name:     "empty prefix",
+			prefixes: []string{"svc/api", ""},
+			want: []ComponentSummary{
+				{Prefix: "svc/api", Errors: 1, Warnings: 1},
+				{Prefix: "", Errors: 1, Warnings: 1, Notes: 2, Others: 1},
+			},
+		},
+		{
+			name:     "no prefixes",
+			prefixes: nil,
+			want: []ComponentSummary{
+				{Prefix: "(other)", Errors: 2, Warnings: 2, Notes: 2, Others: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := l.SummarizeComponents(tt.prefixes)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("summaries mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func init() {
+	RegisterConverter("quickfix", quickfixConverter{})
+}
+
+// EncodeQuickfix writes the results of the [Log] to w in the
+// "file:line:col: level: message" format understood by the quickfix
+// list of Vim and the compilation mode of Emacs. Every location of
+// every result produces a line. Artifact locations are resolved with
+// [Run.ResolveArtifactLocation] and written as OS paths, relative to
+// the current directory if they cannot be fully resolved. Artifact
+// URIs with a scheme different from "file" are written as is. Line and
+// column numbers are omitted when not known. Results without level
+// are reported as warnings and only the first line of multi-line
+// messages is written. Results without locations are ignored.
+func (l Log) EncodeQuickfix(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, run := range l.Runs {
+		for _, result := range run.Results {
+			level := result.Level
+			if level == "" {
+				level = "warning"
+			}
+			msg, _, _ := strings.Cut(result.Message.Text, "\n")
+
+			for _, loc := range result.Locations {
+				ploc := loc.PhysicalLocation
+				aloc, err := run.ResolveArtifactLocation(ploc.ArtifactLocation)
+				if err != nil {
+					return err
+				}
+				p, err := aloc.Path()
+				if errors.Is(err, errUnsupportedScheme) {
+					p, err = aloc.URI, nil
+				}
+				if err != nil {
+					return err
+				}
+
+				s := p
+				if ploc.Region.StartLine != 0 {
+					s += fmt.Sprintf(":%v", ploc.Region.StartLine)
+					if ploc.Region.StartColumn != 0 {
+						s += fmt.Sprintf(":%v", ploc.Region.StartColumn)
+					}
+				}
+				fmt.Fprintf(bw, "%v: %v: %v\n", s, level, msg)
+			}
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write quickfix output: %w", err)
+	}
+	return nil
+}
+
+// quickfixConverter converts SARIF documents into the quickfix
+// format. It is registered with the name "quickfix".
+type quickfixConverter struct{}
+
+// FromNative returns [ErrUnsupportedConversion].
+func (quickfixConverter) FromNative(r io.Reader) (Log, error) {
+	return Log{}, ErrUnsupportedConversion
+}
+
+// ToNative writes the results of the [Log] using
+// [Log.EncodeQuickfix].
+func (quickfixConverter) ToNative(l Log, w io.Writer) error {
+	return l.EncodeQuickfix(w)
+}
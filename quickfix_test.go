@@ -0,0 +1,95 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLog_EncodeQuickfix(t *testing.T) {
+	l := Log{
+		Version: "2.1.0",
+		Runs: []Run{
+			{
+				OriginalURIBaseIDs: map[string]ArtifactLocation{
+					"SRCROOT": {URI: "src/"},
+				},
+				Results: []Result{
+					{
+						Level:   "error",
+						Message: Description{Text: "First line.\nSecond line."},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.go", URIBaseID: "SRCROOT"},
+									Region:           Region{StartLine: 3, StartColumn: 5},
+								},
+							},
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "my%20file.go"},
+									Region:           Region{StartLine: 7},
+								},
+							},
+						},
+					},
+					{
+						Message: Description{Text: "No level."},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "b.go", URIBaseID: "UNKNOWN"},
+								},
+							},
+						},
+					},
+					{
+						Level:   "warning",
+						Message: Description{Text: "Remote artifact."},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "https://example.com/a.go"},
+									Region:           Region{StartLine: 2},
+								},
+							},
+						},
+					},
+					{
+						Level:   "note",
+						Message: Description{Text: "No location."},
+					},
+				},
+			},
+		},
+	}
+
+	want := filepath.FromSlash("src/a.go") + ":3:5: error: First line.\n" +
+		"my file.go:7: error: First line.\n" +
+		"b.go: warning: No level.\n" +
+		"https://example.com/a.go:2: warning: Remote artifact.\n"
+
+	var buf bytes.Buffer
+	if err := l.EncodeQuickfix(&buf); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("quickfix output mismatch (-want +got):\n%v", diff)
+	}
+
+	c, found := LookupConverter("quickfix")
+	if !found {
+		t.Fatalf("converter not found")
+	}
+	buf.Reset()
+	if err := c.ToNative(l, &buf); err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("converter output mismatch (-want +got):\n%v", diff)
+	}
+}
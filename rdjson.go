@@ -0,0 +1,342 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+func init() {
+	RegisterConverter("rdjson", rdjsonConverter{})
+}
+
+// rdjsonConverter converts between SARIF and the JSON encoding of the
+// [Reviewdog Diagnostic Format]. It is registered with the name
+// "rdjson". Column numbers are copied as they are, so they are only
+// accurate if the artifacts only contain ASCII characters or the run
+// measures columns in bytes.
+//
+// [Reviewdog Diagnostic Format]: https://github.com/reviewdog/reviewdog/tree/master/proto/rdf
+type rdjsonConverter struct{}
+
+// rdjsonResult is a Reviewdog DiagnosticResult.
+type rdjsonResult struct {
+	Source      *rdjsonSource      `json:"source,omitempty"`
+	Severity    string             `json:"severity,omitempty"`
+	Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+}
+
+// rdjsonDiagnostic is a Reviewdog Diagnostic.
+type rdjsonDiagnostic struct {
+	Message          string                  `json:"message"`
+	Location         rdjsonLocation          `json:"location"`
+	Severity         string                  `json:"severity,omitempty"`
+	Source           *rdjsonSource           `json:"source,omitempty"`
+	Code             *rdjsonCode             `json:"code,omitempty"`
+	Suggestions      []rdjsonSuggestion      `json:"suggestions,omitempty"`
+	OriginalOutput   string                  `json:"original_output,omitempty"`
+	RelatedLocations []rdjsonRelatedLocation `json:"related_locations,omitempty"`
+}
+
+// rdjsonSource is a Reviewdog Source.
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// rdjsonCode is a Reviewdog Code.
+type rdjsonCode struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+// rdjsonLocation is a Reviewdog Location.
+type rdjsonLocation struct {
+	Path  string       `json:"path"`
+	Range *rdjsonRange `json:"range,omitempty"`
+}
+
+// rdjsonRange is a Reviewdog Range.
+type rdjsonRange struct {
+	Start rdjsonPosition  `json:"start"`
+	End   *rdjsonPosition `json:"end,omitempty"`
+}
+
+// rdjsonPosition is a Reviewdog Position.
+type rdjsonPosition struct {
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+// rdjsonSuggestion is a Reviewdog Suggestion.
+type rdjsonSuggestion struct {
+	Range rdjsonRange `json:"range"`
+	Text  string      `json:"text"`
+}
+
+// rdjsonRelatedLocation is a Reviewdog RelatedLocation.
+type rdjsonRelatedLocation struct {
+	Message  string         `json:"message,omitempty"`
+	Location rdjsonLocation `json:"location"`
+}
+
+// FromNative decodes the Reviewdog DiagnosticResult read from r and
+// converts it into a [Log] with a single run. The source of the
+// result becomes the driver and the codes of the diagnostics become
+// its rules.
+func (rdjsonConverter) FromNative(r io.Reader) (Log, error) {
+	var rdr rdjsonResult
+	if err := json.NewDecoder(r).Decode(&rdr); err != nil {
+		return Log{}, fmt.Errorf("decode rdjson document: %w", err)
+	}
+
+	run := Run{Results: []Result{}}
+	if rdr.Source != nil {
+		run.Tool.Driver.Name = rdr.Source.Name
+		run.Tool.Driver.InformationURI = rdr.Source.URL
+	}
+
+	rules := make(map[string]bool)
+	for _, d := range rdr.Diagnostics {
+		severity := d.Severity
+		if severity == "" {
+			severity = rdr.Severity
+		}
+		result := Result{
+			Level:   rdjsonLevel(severity),
+			Message: Description{Text: d.Message},
+		}
+		if d.Location.Path != "" {
+			result.Locations = []Location{{PhysicalLocation: d.Location.physicalLocation()}}
+		}
+
+		if d.Code != nil && d.Code.Value != "" {
+			result.RuleID = d.Code.Value
+			if !rules[d.Code.Value] {
+				rules[d.Code.Value] = true
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, Rule{
+					ID:      d.Code.Value,
+					HelpURI: d.Code.URL,
+				})
+			}
+		}
+
+		if len(d.Suggestions) > 0 {
+			change := ArtifactChange{
+				ArtifactLocation: NewArtifactLocation(d.Location.Path, ""),
+			}
+			for _, s := range d.Suggestions {
+				change.Replacements = append(change.Replacements, Replacement{
+					DeletedRegion:   s.Range.region(),
+					InsertedContent: &ArtifactContent{Text: s.Text},
+				})
+			}
+			result.Fixes = []Fix{{ArtifactChanges: []ArtifactChange{change}}}
+		}
+
+		for _, rl := range d.RelatedLocations {
+			result.RelatedLocations = append(result.RelatedLocations, Location{
+				PhysicalLocation: rl.Location.physicalLocation(),
+				Message:          Description{Text: rl.Message},
+			})
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	return Log{
+		Version: sarifVersion,
+		Runs:    []Run{run},
+	}, nil
+}
+
+// ToNative converts the results of the [Log] into a Reviewdog
+// DiagnosticResult and writes its JSON encoding to w. Every result
+// becomes a diagnostic located at its first location. The replacements
+// of its fixes that apply to that artifact become suggestions.
+func (rdjsonConverter) ToNative(l Log, w io.Writer) error {
+	rdr := rdjsonResult{Diagnostics: []rdjsonDiagnostic{}}
+	for i, run := range l.Runs {
+		source := &rdjsonSource{
+			Name: run.Tool.Driver.Name,
+			URL:  run.Tool.Driver.InformationURI,
+		}
+		if i == 0 {
+			rdr.Source = source
+		}
+
+		for _, result := range run.Results {
+			d := rdjsonDiagnostic{
+				Message:  result.Message.Text,
+				Severity: rdjsonSeverity(result.Level),
+				Source:   source,
+			}
+
+			if rule, found := run.ResultRule(result); found {
+				d.Code = &rdjsonCode{Value: rule.ID, URL: rule.HelpURI}
+			} else if result.RuleID != "" {
+				d.Code = &rdjsonCode{Value: result.RuleID}
+			}
+
+			loc, err := rdjsonNewLocation(run, firstPhysicalLocation(result))
+			if err != nil {
+				return err
+			}
+			d.Location = loc
+
+			for _, fix := range result.Fixes {
+				for _, change := range fix.ArtifactChanges {
+					p, err := rdjsonPath(run, change.ArtifactLocation)
+					if err != nil {
+						return err
+					}
+					if p != d.Location.Path {
+						continue
+					}
+					for _, r := range change.Replacements {
+						var text string
+						if r.InsertedContent != nil {
+							text = r.InsertedContent.Text
+						}
+						d.Suggestions = append(d.Suggestions, rdjsonSuggestion{
+							Range: rdjsonNewRange(r.DeletedRegion),
+							Text:  text,
+						})
+					}
+				}
+			}
+
+			for _, rl := range result.RelatedLocations {
+				loc, err := rdjsonNewLocation(run, rl.PhysicalLocation)
+				if err != nil {
+					return err
+				}
+				d.RelatedLocations = append(d.RelatedLocations, rdjsonRelatedLocation{
+					Message:  rl.Message.Text,
+					Location: loc,
+				})
+			}
+
+			rdr.Diagnostics = append(rdr.Diagnostics, d)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(rdr); err != nil {
+		return fmt.Errorf("encode rdjson document: %w", err)
+	}
+	return nil
+}
+
+// physicalLocation returns the [PhysicalLocation] corresponding to the
+// Reviewdog location.
+func (loc rdjsonLocation) physicalLocation() PhysicalLocation {
+	ploc := PhysicalLocation{
+		ArtifactLocation: NewArtifactLocation(loc.Path, ""),
+	}
+	if loc.Range != nil {
+		ploc.Region = loc.Range.region()
+	}
+	return ploc
+}
+
+// region returns the [Region] corresponding to the Reviewdog range.
+func (rng rdjsonRange) region() Region {
+	region := Region{
+		StartLine:   rng.Start.Line,
+		StartColumn: rng.Start.Column,
+	}
+	if rng.End != nil {
+		region.EndLine = rng.End.Line
+		region.EndColumn = rng.End.Column
+	}
+	return region
+}
+
+// rdjsonNewLocation returns the Reviewdog location corresponding to
+// the provided physical location of a result of run.
+func rdjsonNewLocation(run Run, ploc PhysicalLocation) (rdjsonLocation, error) {
+	if ploc.ArtifactLocation.URI == "" {
+		return rdjsonLocation{}, nil
+	}
+
+	p, err := rdjsonPath(run, ploc.ArtifactLocation)
+	if err != nil {
+		return rdjsonLocation{}, err
+	}
+	loc := rdjsonLocation{Path: p}
+	if ploc.Region.StartLine != 0 {
+		rng := rdjsonNewRange(ploc.Region)
+		loc.Range = &rng
+	}
+	return loc, nil
+}
+
+// rdjsonNewRange returns the Reviewdog range corresponding to the
+// provided region.
+func rdjsonNewRange(region Region) rdjsonRange {
+	rng := rdjsonRange{
+		Start: rdjsonPosition{
+			Line:   region.StartLine,
+			Column: region.StartColumn,
+		},
+	}
+	if region.EndLine != 0 || region.EndColumn != 0 {
+		rng.End = &rdjsonPosition{
+			Line:   max(region.EndLine, region.StartLine),
+			Column: region.EndColumn,
+		}
+	}
+	return rng
+}
+
+// rdjsonPath returns the slash-separated path of the provided artifact
+// location of a result of run. If the artifact URI has a scheme
+// different from "file", the URI is returned as is.
+func rdjsonPath(run Run, loc ArtifactLocation) (string, error) {
+	loc, err := run.ResolveArtifactLocation(loc)
+	if err != nil {
+		return "", err
+	}
+	p, err := loc.Path()
+	if errors.Is(err, errUnsupportedScheme) {
+		return loc.URI, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(p), nil
+}
+
+// rdjsonLevel returns the SARIF level corresponding to the provided
+// Reviewdog severity.
+func rdjsonLevel(severity string) string {
+	switch severity {
+	case "ERROR":
+		return "error"
+	case "WARNING":
+		return "warning"
+	case "INFO":
+		return "note"
+	}
+	return ""
+}
+
+// rdjsonSeverity returns the Reviewdog severity corresponding to the
+// provided SARIF level.
+func rdjsonSeverity(level string) string {
+	switch level {
+	case "error":
+		return "ERROR"
+	case "warning", "":
+		return "WARNING"
+	case "note":
+		return "INFO"
+	}
+	return ""
+}
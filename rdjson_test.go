@@ -0,0 +1,197 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const rdjsonDocument = `{
+  "source": {
+    "name": "linter",
+    "url": "https://example.com/linter"
+  },
+  "diagnostics": [
+    {
+      "message": "Use of gets.",
+      "location": {
+        "path": "src/a.c",
+        "range": {
+          "start": {"line": 10, "column": 5},
+          "end": {"line": 10, "column": 9}
+        }
+      },
+      "severity": "ERROR",
+      "code": {"value": "no-gets", "url": "https://example.com/no-gets"},
+      "suggestions": [
+        {
+          "range": {
+            "start": {"line": 10, "column": 5},
+            "end": {"line": 10, "column": 9}
+          },
+          "text": "fgets"
+        }
+      ],
+      "related_locations": [
+        {
+          "message": "Buffer declared here.",
+          "location": {
+            "path": "src/a.c",
+            "range": {"start": {"line": 3}}
+          }
+        }
+      ]
+    },
+    {
+      "message": "Project has no license.",
+      "location": {"path": ""},
+      "severity": "INFO"
+    }
+  ]
+}`
+
+func TestRdjsonConverter_FromNative(t *testing.T) {
+	want := Log{
+		Version: "2.1.0",
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Name:           "linter",
+						InformationURI: "https://example.com/linter",
+						Rules: []Rule{
+							{ID: "no-gets", HelpURI: "https://example.com/no-gets"},
+						},
+					},
+				},
+				Results: []Result{
+					{
+						RuleID:  "no-gets",
+						Level:   "error",
+						Message: Description{Text: "Use of gets."},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "src/a.c"},
+									Region:           Region{StartLine: 10, StartColumn: 5, EndLine: 10, EndColumn: 9},
+								},
+							},
+						},
+						RelatedLocations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "src/a.c"},
+									Region:           Region{StartLine: 3},
+								},
+								Message: Description{Text: "Buffer declared here."},
+							},
+						},
+						Fixes: []Fix{
+							{
+								ArtifactChanges: []ArtifactChange{
+									{
+										ArtifactLocation: ArtifactLocation{URI: "src/a.c"},
+										Replacements: []Replacement{
+											{
+												DeletedRegion:   Region{StartLine: 10, StartColumn: 5, EndLine: 10, EndColumn: 9},
+												InsertedContent: &ArtifactContent{Text: "fgets"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						Level:   "note",
+						Message: Description{Text: "Project has no license."},
+					},
+				},
+			},
+		},
+	}
+
+	c, found := LookupConverter("rdjson")
+	if !found {
+		t.Fatalf("converter not found")
+	}
+
+	got, err := c.FromNative(strings.NewReader(rdjsonDocument))
+	if err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("log mismatch (-want +got):\n%v", diff)
+	}
+
+	if _, err := c.FromNative(strings.NewReader("{")); err == nil {
+		t.Errorf("expected non-nil error")
+	}
+}
+
+func TestRdjsonConverter_ToNative(t *testing.T) {
+	c, found := LookupConverter("rdjson")
+	if !found {
+		t.Fatalf("converter not found")
+	}
+
+	l, err := c.FromNative(strings.NewReader(rdjsonDocument))
+	if err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := c.ToNative(l, &buf); err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+
+	var want, got rdjsonResult
+	if err := json.Unmarshal([]byte(rdjsonDocument), &want); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	for i := range want.Diagnostics {
+		want.Diagnostics[i].Source = want.Source
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("rdjson mismatch (-want +got):\n%v", diff)
+	}
+
+	remote := Log{
+		Version: "2.1.0",
+		Runs: []Run{
+			{
+				Results: []Result{
+					{
+						Message: Description{Text: "Remote artifact."},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "https://example.com/a.go"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	buf.Reset()
+	if err := c.ToNative(remote, &buf); err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+	got = rdjsonResult{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if p := got.Diagnostics[0].Location.Path; p != "https://example.com/a.go" {
+		t.Errorf("path mismatch: want: https://example.com/a.go, got: %v", p)
+	}
+}
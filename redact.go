@@ -0,0 +1,102 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultRedactionToken is the redaction token used by [Run.Redact]
+// when the run does not define one.
+const DefaultRedactionToken = "[REDACTED]"
+
+// Redact returns a copy of the run where every occurrence of the
+// provided sensitive strings in the messages and text contents of the
+// run, such as result messages, message arguments and snippets, and in
+// the command lines, command-line arguments and environment variables
+// of the invocations is replaced with the first redaction token of the
+// run. If the run has no redaction tokens, [DefaultRedactionToken] is
+// used and added to [Run.RedactionTokens]. Longer sensitive strings
+// take precedence over shorter ones. The original JSON encoding of the
+// results is not kept, since it would contain the sensitive strings.
+func (run Run) Redact(secrets []string) (Run, error) {
+	secrets = slices.DeleteFunc(slices.Clone(secrets), func(s string) bool { return s == "" })
+	if len(secrets) == 0 {
+		return run, nil
+	}
+	slices.SortStableFunc(secrets, func(a, b string) int {
+		return cmp.Compare(len(b), len(a))
+	})
+
+	token := DefaultRedactionToken
+	if len(run.RedactionTokens) > 0 {
+		token = run.RedactionTokens[0]
+	}
+	var oldnew []string
+	for _, s := range secrets {
+		oldnew = append(oldnew, s, token)
+	}
+	replacer := strings.NewReplacer(oldnew...)
+
+	doc, err := toDocument(run)
+	if err != nil {
+		return Run{}, err
+	}
+	redacted := false
+	doc = redactNode(doc, false, replacer, &redacted)
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return Run{}, fmt.Errorf("marshal JSON document: %w", err)
+	}
+	var r Run
+	if err := json.Unmarshal(b, &r); err != nil {
+		return Run{}, fmt.Errorf("unmarshal JSON document: %w", err)
+	}
+	if redacted && len(r.RedactionTokens) == 0 {
+		r.RedactionTokens = []string{token}
+	}
+	return r, nil
+}
+
+// redactedMembers contains the members of a SARIF document whose
+// strings may contain sensitive strings. The strings within arrays
+// and objects, such as the values of "environmentVariables", are
+// redacted too.
+var redactedMembers = map[string]bool{
+	"text":                 true,
+	"markdown":             true,
+	"arguments":            true,
+	"commandLine":          true,
+	"environmentVariables": true,
+}
+
+// redactNode replaces the sensitive strings in the generic JSON
+// document node. If redact is false, only the strings within the
+// members listed in [redactedMembers] are replaced. It sets redacted
+// to true if any string is replaced.
+func redactNode(node any, redact bool, replacer *strings.Replacer, redacted *bool) any {
+	switch v := node.(type) {
+	case map[string]any:
+		for k, child := range v {
+			v[k] = redactNode(child, redact || redactedMembers[k], replacer, redacted)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactNode(child, redact, replacer, redacted)
+		}
+	case string:
+		if !redact {
+			return v
+		}
+		if s := replacer.Replace(v); s != v {
+			*redacted = true
+			return s
+		}
+	}
+	return node
+}
@@ -0,0 +1,333 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun_Redact(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        Run
+		secrets    []string
+		want       Run
+		wantNilErr bool
+	}{
+		{
+			name: "default token",
+			run: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:     "Token hunter2 found.",
+							Markdown: "`Token hunter2 found.`",
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "hunter2.txt"},
+									Region: Region{
+										StartLine: 1,
+										Snippet:   &ArtifactContent{Text: `pass = "hunter2"`},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			secrets: []string{"hunter2"},
+			want: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:     "Token [REDACTED] found.",
+							Markdown: "`Token [REDACTED] found.`",
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "hunter2.txt"},
+									Region: Region{
+										StartLine: 1,
+										Snippet:   &ArtifactContent{Text: `pass = "[REDACTED]"`},
+									},
+								},
+							},
+						},
+					},
+				},
+				RedactionTokens: []string{"[REDACTED]"},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "run token",
+			run: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:     "Token hunter2 found.",
+							Markdown: "`Token hunter2 found.`",
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.txt"},
+									Region:           Region{StartLine: 1},
+								},
+							},
+						},
+					},
+				},
+				RedactionTokens: []string{"***"},
+			},
+			secrets: []string{"hunter2"},
+			want: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:     "Token *** found.",
+							Markdown: "`Token *** found.`",
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.txt"},
+									Region:           Region{StartLine: 1},
+								},
+							},
+						},
+					},
+				},
+				RedactionTokens: []string{"***"},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "longest first",
+			run: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:     "Keys abc and abcdef.",
+							Markdown: "`Keys abc and abcdef.`",
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.txt"},
+									Region:           Region{StartLine: 1},
+								},
+							},
+						},
+					},
+				},
+			},
+			secrets: []string{"abc", "", "abcdef"},
+			want: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:     "Keys [REDACTED] and [REDACTED].",
+							Markdown: "`Keys [REDACTED] and [REDACTED].`",
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.txt"},
+									Region:           Region{StartLine: 1},
+								},
+							},
+						},
+					},
+				},
+				RedactionTokens: []string{"[REDACTED]"},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "message arguments",
+			run: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:      "Token {0} found.",
+							Markdown:  "`Token {0} found.`",
+							Arguments: []string{"hunter2", "other"},
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.txt"},
+									Region:           Region{StartLine: 1},
+								},
+							},
+						},
+					},
+				},
+			},
+			secrets: []string{"hunter2"},
+			want: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:      "Token {0} found.",
+							Markdown:  "`Token {0} found.`",
+							Arguments: []string{"[REDACTED]", "other"},
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.txt"},
+									Region:           Region{StartLine: 1},
+								},
+							},
+						},
+					},
+				},
+				RedactionTokens: []string{"[REDACTED]"},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "command line",
+			run: Run{
+				Invocations: []Invocation{
+					{
+						CommandLine: "tool --token=hunter2",
+						Arguments:   []string{"--token=hunter2"},
+					},
+				},
+			},
+			secrets: []string{"hunter2"},
+			want: Run{
+				Invocations: []Invocation{
+					{
+						CommandLine: "tool --token=[REDACTED]",
+						Arguments:   []string{"--token=[REDACTED]"},
+					},
+				},
+				RedactionTokens: []string{"[REDACTED]"},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "environment variables",
+			run: Run{
+				Invocations: []Invocation{
+					{
+						EnvironmentVariables: map[string]string{"TOKEN": "hunter2", "HOME": "/home/user"},
+					},
+				},
+			},
+			secrets: []string{"hunter2"},
+			want: Run{
+				Invocations: []Invocation{
+					{
+						EnvironmentVariables: map[string]string{"TOKEN": "[REDACTED]", "HOME": "/home/user"},
+					},
+				},
+				RedactionTokens: []string{"[REDACTED]"},
+			},
+			wantNilErr: true,
+		},
+		{
+			name: "nothing to redact",
+			run: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:     "No secrets.",
+							Markdown: "`No secrets.`",
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.txt"},
+									Region:           Region{StartLine: 1},
+								},
+							},
+						},
+					},
+				},
+			},
+			secrets: []string{"hunter2"},
+			want: Run{
+				Results: []Result{
+					{
+						RuleID: "secret",
+						Message: Description{
+							Text:     "No secrets.",
+							Markdown: "`No secrets.`",
+						},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.txt"},
+									Region:           Region{StartLine: 1},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantNilErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.run.Redact(tt.secrets)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("run mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestRun_RedactRaw(t *testing.T) {
+	run := Run{
+		Results: []Result{
+			{
+				Message: Description{Text: "hunter2"},
+				Raw:     json.RawMessage(`{"message": {"text": "hunter2"}}`),
+			},
+		},
+	}
+
+	got, err := run.Redact([]string{"hunter2"})
+	if err != nil {
+		t.Fatalf("redact error: %v", err)
+	}
+	if got.Results[0].Raw != nil {
+		t.Errorf("raw result was kept: %s", got.Results[0].Raw)
+	}
+	if run.Results[0].Message.Text != "hunter2" {
+		t.Errorf("input run was modified")
+	}
+}
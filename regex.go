@@ -0,0 +1,163 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RegexConverter is a [Converter] that turns the line-based output of
+// a tool into SARIF. Every line matching the pattern produces a
+// result. The pattern can use the following named groups:
+//
+//   - file: path of the artifact.
+//   - line: start line.
+//   - col: start column.
+//   - severity: severity of the result. See [RegexConverter.FromNative]
+//     for how it is mapped to a level.
+//   - rule: rule identifier.
+//   - message: message text.
+//
+// Lines that do not match the pattern are ignored. Only the
+// conversion into SARIF is supported.
+type RegexConverter struct {
+	toolName string
+	re       *regexp.Regexp
+}
+
+// regexGroups are the named groups supported by [RegexConverter].
+var regexGroups = []string{"file", "line", "col", "severity", "rule", "message"}
+
+// NewRegexConverter returns a [RegexConverter] for the tool with the
+// provided name. It returns error if pattern is not a valid regular
+// expression, if it contains unsupported named groups or if it does
+// not contain the message group.
+func NewRegexConverter(toolName, pattern string) (*RegexConverter, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("compile pattern: %w", err)
+	}
+
+	names := re.SubexpNames()
+	for _, name := range names {
+		if name != "" && !slices.Contains(regexGroups, name) {
+			return nil, fmt.Errorf("unsupported named group: %v", name)
+		}
+	}
+	if !slices.Contains(names, "message") {
+		return nil, errors.New("missing message group")
+	}
+
+	return &RegexConverter{toolName: toolName, re: re}, nil
+}
+
+// FromNative reads the output of the tool from r and converts it into
+// a [Log] with a single run. The rules referenced by the results are
+// declared in the driver in the order in which they first appear.
+//
+// The severity is matched case-insensitively. "error", "err", "fatal",
+// "critical" and "high" are mapped to the "error" level. "note",
+// "info", "information", "hint" and "low" are mapped to the "note"
+// level. "none" is mapped to the "none" level. Any other severity is
+// mapped to the "warning" level. Results without severity have no
+// level.
+func (c *RegexConverter) FromNative(r io.Reader) (Log, error) {
+	run := Run{
+		Tool: Tool{
+			Driver: Driver{Name: c.toolName},
+		},
+		Results: []Result{},
+	}
+
+	rules := make(map[string]bool)
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		m := c.re.FindStringSubmatch(s.Text())
+		if m == nil {
+			continue
+		}
+
+		group := func(name string) string {
+			if i := c.re.SubexpIndex(name); i >= 0 {
+				return m[i]
+			}
+			return ""
+		}
+
+		result := Result{
+			RuleID:  group("rule"),
+			Level:   severityLevel(group("severity")),
+			Message: Description{Text: group("message")},
+		}
+
+		if file := group("file"); file != "" {
+			var (
+				line, col int
+				err       error
+			)
+			if v := group("line"); v != "" {
+				if line, err = strconv.Atoi(v); err != nil {
+					return Log{}, fmt.Errorf("line %v: invalid line number: %v", n, v)
+				}
+			}
+			if v := group("col"); v != "" {
+				if col, err = strconv.Atoi(v); err != nil {
+					return Log{}, fmt.Errorf("line %v: invalid column number: %v", n, v)
+				}
+			}
+			result.Locations = []Location{
+				{
+					PhysicalLocation: PhysicalLocation{
+						ArtifactLocation: NewArtifactLocation(file, ""),
+						Region: Region{
+							StartLine:   line,
+							StartColumn: col,
+						},
+					},
+				},
+			}
+		}
+
+		if result.RuleID != "" && !rules[result.RuleID] {
+			rules[result.RuleID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, Rule{ID: result.RuleID})
+		}
+		run.Results = append(run.Results, result)
+	}
+	if err := s.Err(); err != nil {
+		return Log{}, fmt.Errorf("read tool output: %w", err)
+	}
+
+	return Log{
+		Version: sarifVersion,
+		Runs:    []Run{run},
+	}, nil
+}
+
+// ToNative returns [ErrUnsupportedConversion].
+func (c *RegexConverter) ToNative(l Log, w io.Writer) error {
+	return ErrUnsupportedConversion
+}
+
+// severityLevel returns the SARIF level corresponding to the provided
+// tool severity.
+func severityLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "":
+		return ""
+	case "error", "err", "fatal", "critical", "high":
+		return "error"
+	case "note", "info", "information", "hint", "low":
+		return "note"
+	case "none":
+		return "none"
+	}
+	return "warning"
+}
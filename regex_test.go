@@ -0,0 +1,168 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewRegexConverter(t *testing.T) {
+	tests := []struct {
+		name       string
+		pattern    string
+		wantNilErr bool
+	}{
+		{
+			name:       "valid",
+			pattern:    `^(?P<file>[^:]+):(?P<line>\d+): (?P<message>.*)$`,
+			wantNilErr: true,
+		},
+		{
+			name:       "invalid regexp",
+			pattern:    `(?P<message>.*`,
+			wantNilErr: false,
+		},
+		{
+			name:       "unsupported group",
+			pattern:    `(?P<code>\d+) (?P<message>.*)`,
+			wantNilErr: false,
+		},
+		{
+			name:       "missing message",
+			pattern:    `(?P<file>.*)`,
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegexConverter("tool", tt.pattern)
+			if (err == nil) != tt.wantNilErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRegexConverter_FromNative(t *testing.T) {
+	const pattern = `^(?P<file>[^:]+):(?P<line>[^:]+):(?P<col>\d+): (?P<severity>\w+) \[(?P<rule>[\w-]+)\] (?P<message>.*)$`
+
+	tests := []struct {
+		name       string
+		input      string
+		want       Log
+		wantNilErr bool
+	}{
+		{
+			name: "valid",
+			input: "Checking files...\n" +
+				"src/a.c:10:5: ERROR [no-gets] Use of gets.\n" +
+				"src/b.c:3:1: warn [unused] Unused variable.\n" +
+				"src/a.c:12:7: info [no-gets] Use of gets.\n",
+			want: Log{
+				Version: "2.1.0",
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{
+								Name:  "lint",
+								Rules: []Rule{{ID: "no-gets"}, {ID: "unused"}},
+							},
+						},
+						Results: []Result{
+							{
+								RuleID:    "no-gets",
+								Level:     "error",
+								Message:   Description{Text: "Use of gets."},
+								Locations: regexLocations("src/a.c", 10, 5),
+							},
+							{
+								RuleID:    "unused",
+								Level:     "warning",
+								Message:   Description{Text: "Unused variable."},
+								Locations: regexLocations("src/b.c", 3, 1),
+							},
+							{
+								RuleID:    "no-gets",
+								Level:     "note",
+								Message:   Description{Text: "Use of gets."},
+								Locations: regexLocations("src/a.c", 12, 7),
+							},
+						},
+					},
+				},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:  "no matches",
+			input: "All good.\n",
+			want: Log{
+				Version: "2.1.0",
+				Runs: []Run{
+					{
+						Tool:    Tool{Driver: Driver{Name: "lint"}},
+						Results: []Result{},
+					},
+				},
+			},
+			wantNilErr: true,
+		},
+		{
+			name:       "invalid line",
+			input:      "src/a.c:x:5: error [no-gets] Use of gets.\n",
+			wantNilErr: false,
+		},
+	}
+
+	c, err := NewRegexConverter("lint", pattern)
+	if err != nil {
+		t.Fatalf("new converter: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.FromNative(strings.NewReader(tt.input))
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("log mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestRegexConverter_ToNative(t *testing.T) {
+	c, err := NewRegexConverter("lint", `(?P<message>.*)`)
+	if err != nil {
+		t.Fatalf("new converter: %v", err)
+	}
+	if err := c.ToNative(Log{}, io.Discard); !errors.Is(err, ErrUnsupportedConversion) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func regexLocations(file string, line, col int) []Location {
+	return []Location{
+		{
+			PhysicalLocation: PhysicalLocation{
+				ArtifactLocation: ArtifactLocation{URI: file},
+				Region:           Region{StartLine: line, StartColumn: col},
+			},
+		},
+	}
+}
@@ -7,11 +7,16 @@
 package sarif
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"reflect"
+	"slices"
+	"time"
 )
 
 const (
@@ -38,35 +43,231 @@ type Log struct {
 
 	// Runs contains the data provided by the executed tools.
 	Runs []Run `json:"runs,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// DecodeOption configures how a SARIF document is decoded.
+type DecodeOption func(*decodeOptions)
+
+// decodeOptions contains the configuration set by the provided
+// [DecodeOption] values.
+type decodeOptions struct {
+	rawResults bool
+	strict     bool
+	maxSize    int64
+	laxVersion bool
+}
+
+// WithRawResults makes the decoder retain the original JSON encoding
+// of every result in [Result.Raw].
+func WithRawResults() DecodeOption {
+	return func(o *decodeOptions) {
+		o.rawResults = true
+	}
+}
+
+// WithStrict makes the decoder return error if the SARIF document
+// contains fields that are not supported by this package.
+func WithStrict() DecodeOption {
+	return func(o *decodeOptions) {
+		o.strict = true
+	}
+}
+
+// WithMaxSize makes the decoder return error if the SARIF document is
+// larger than n bytes.
+func WithMaxSize(n int64) DecodeOption {
+	return func(o *decodeOptions) {
+		o.maxSize = n
+	}
+}
+
+// WithLaxVersion makes the decoder accept SARIF documents of any
+// version.
+func WithLaxVersion() DecodeOption {
+	return func(o *decodeOptions) {
+		o.laxVersion = true
+	}
 }
 
 // Decode reads a SARIF document from the provided [io.Reader] and
 // returns the decoded [Log] value.
-func Decode(r io.Reader) (Log, error) {
+func Decode(r io.Reader, opts ...DecodeOption) (Log, error) {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.maxSize > 0 {
+		r = &limitedReader{r: r, n: o.maxSize}
+	}
+
+	dec := json.NewDecoder(r)
+	var raw json.RawMessage
+	if o.rawResults {
+		if err := dec.Decode(&raw); err != nil {
+			return Log{}, fmt.Errorf("decode SARIF document: %w", err)
+		}
+		dec = json.NewDecoder(bytes.NewReader(raw))
+	}
+	if o.strict {
+		dec.DisallowUnknownFields()
+	}
+
 	var l Log
-	if err := json.NewDecoder(r).Decode(&l); err != nil {
+	if err := dec.Decode(&l); err != nil {
 		return Log{}, fmt.Errorf("decode SARIF document: %w", err)
 	}
-	if l.Version != sarifVersion {
+	if o.rawResults {
+		if err := setRawResults(l, raw); err != nil {
+			return Log{}, err
+		}
+	}
+	if !o.laxVersion && l.Version != sarifVersion {
 		return Log{}, fmt.Errorf("unsupported SARIF version: %v", l.Version)
 	}
 	return l, nil
 }
 
+// DecodeAll reads all the SARIF documents from the provided
+// [io.Reader] and returns the decoded [Log] values. The reader can
+// contain a sequence of concatenated documents, top-level JSON arrays
+// of documents or a mix of both. The provided options are applied to
+// every document, except [WithMaxSize], which limits the size of the
+// whole input.
+func DecodeAll(r io.Reader, opts ...DecodeOption) ([]Log, error) {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.maxSize > 0 {
+		r = &limitedReader{r: r, n: o.maxSize}
+	}
+
+	var logs []Log
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("decode SARIF document: %w", err)
+		}
+
+		docs := []json.RawMessage{raw}
+		if bytes.HasPrefix(raw, []byte("[")) {
+			if err := json.Unmarshal(raw, &docs); err != nil {
+				return nil, fmt.Errorf("decode SARIF document array: %w", err)
+			}
+		}
+
+		for _, doc := range docs {
+			l, err := Decode(bytes.NewReader(doc), opts...)
+			if err != nil {
+				return nil, fmt.Errorf("SARIF document %v: %w", len(logs), err)
+			}
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+// setRawResults sets [Result.Raw] for every result in l using the
+// provided JSON encoding of the log.
+func setRawResults(l Log, raw json.RawMessage) error {
+	var rawLog struct {
+		Runs []struct {
+			Results []json.RawMessage `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(raw, &rawLog); err != nil {
+		return fmt.Errorf("decode SARIF document: %w", err)
+	}
+	for i, run := range rawLog.Runs {
+		for j, result := range run.Results {
+			l.Runs[i].Results[j].Raw = result
+		}
+	}
+	return nil
+}
+
+// errTooLarge is returned when a SARIF document exceeds the maximum
+// size set with [WithMaxSize].
+var errTooLarge = errors.New("SARIF document too large")
+
+// limitedReader reads from r but returns [errTooLarge] if more than n
+// bytes are read.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements [io.Reader].
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if lr.n <= 0 {
+		return 0, errTooLarge
+	}
+	if int64(len(p)) > lr.n {
+		p = p[:lr.n]
+	}
+	n, err := lr.r.Read(p)
+	lr.n -= int64(n)
+	return n, err
+}
+
 // DecodeFile reads a SARIF document from the specified file and
 // returns the decoded [Log] value.
-func DecodeFile(name string) (Log, error) {
+func DecodeFile(name string, opts ...DecodeOption) (Log, error) {
 	f, err := os.Open(name)
 	if err != nil {
 		return Log{}, fmt.Errorf("open SARIF file: %w", err)
 	}
 	defer f.Close()
-	return Decode(f)
+	return Decode(f, opts...)
+}
+
+// EncodeOption configures how a SARIF document is encoded.
+type EncodeOption func(*encodeOptions)
+
+// encodeOptions contains the configuration set by the provided
+// [EncodeOption] values.
+type encodeOptions struct {
+	indent    string
+	schemaURI string
+}
+
+// WithIndent sets the string used to indent the SARIF document. If
+// indent is empty, the document is not indented. By default, two
+// spaces are used.
+func WithIndent(indent string) EncodeOption {
+	return func(o *encodeOptions) {
+		o.indent = indent
+	}
+}
+
+// WithSchemaURI sets the JSON schema URI written to the SARIF
+// document when [Log.Schema] is empty.
+func WithSchemaURI(uri string) EncodeOption {
+	return func(o *encodeOptions) {
+		o.schemaURI = uri
+	}
 }
 
 // Encode encodes the [Log] value as a SARIF document and writes the
 // result to the provided [io.Writer].
-func (l Log) Encode(w io.Writer) error {
+func (l Log) Encode(w io.Writer, opts ...EncodeOption) error {
+	o := encodeOptions{
+		indent:    "  ",
+		schemaURI: sarifSchema,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	if l.Version == "" {
 		l.Version = sarifVersion
 	} else if l.Version != sarifVersion {
@@ -74,11 +275,11 @@ func (l Log) Encode(w io.Writer) error {
 	}
 
 	if l.Schema == "" {
-		l.Schema = sarifSchema
+		l.Schema = o.schemaURI
 	}
 
 	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
+	enc.SetIndent("", o.indent)
 	if err := enc.Encode(l); err != nil {
 		return fmt.Errorf("encode SARIF document: %w", err)
 	}
@@ -87,16 +288,51 @@ func (l Log) Encode(w io.Writer) error {
 
 // EncodeFile encodes the [Log] value as a SARIF document and stores
 // the result in the specified file.
-func (l Log) EncodeFile(name string) error {
+func (l Log) EncodeFile(name string, opts ...EncodeOption) error {
 	f, err := os.Create(name)
 	if err != nil {
 		return fmt.Errorf("create SARIF file: %w", err)
 	}
 	defer f.Close()
-	return l.Encode(f)
+	return l.Encode(f, opts...)
+}
+
+// NewCleanLog returns a [Log] with a single run, created with
+// [NewCleanRun], stating that the provided tool ran and did not detect
+// any result.
+func NewCleanLog(tool Tool) Log {
+	return Log{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []Run{NewCleanRun(tool)},
+	}
+}
+
+// IsClean reports whether the log explicitly states that the tools
+// ran and did not detect any result. That is, the log contains at
+// least one run, the results of every run are an empty but present
+// list and no invocation failed.
+func (l Log) IsClean() bool {
+	if len(l.Runs) == 0 {
+		return false
+	}
+	for _, run := range l.Runs {
+		if run.Results == nil || len(run.Results) > 0 {
+			return false
+		}
+		for _, inv := range run.Invocations {
+			if !inv.ExecutionSuccessful {
+				return false
+			}
+		}
+	}
+	return true
 }
 
-// FindRule returns the rule with the provided identifier.
+// FindRule returns the rule with the provided identifier. If no rule
+// has that identifier, it returns the first rule that lists it in
+// [Rule.DeprecatedIDs], so identifiers used by older versions of the
+// tool resolve against the current rules.
 func (l Log) FindRule(id string) (rule Rule, found bool) {
 	for _, run := range l.Runs {
 		for _, rule := range run.Tool.Driver.Rules {
@@ -105,6 +341,26 @@ func (l Log) FindRule(id string) (rule Rule, found bool) {
 			}
 		}
 	}
+	for _, run := range l.Runs {
+		for _, rule := range run.Tool.Driver.Rules {
+			if slices.Contains(rule.DeprecatedIDs, id) {
+				return rule, true
+			}
+		}
+	}
+	return Rule{}, false
+}
+
+// FindNotificationDescriptor returns the notification descriptor with
+// the provided identifier.
+func (l Log) FindNotificationDescriptor(id string) (descriptor Rule, found bool) {
+	for _, run := range l.Runs {
+		for _, descriptor := range run.Tool.Driver.Notifications {
+			if descriptor.ID == id {
+				return descriptor, true
+			}
+		}
+	}
 	return Rule{}, false
 }
 
@@ -117,6 +373,347 @@ type Run struct {
 	// Results contains the results detected in the course of the
 	// run.
 	Results []Result `json:"results,omitempty"`
+
+	// Invocations describes the invocations of the analysis tool
+	// that were involved in the run.
+	Invocations []Invocation `json:"invocations,omitempty"`
+
+	// Artifacts contains the artifacts relevant to the run, such
+	// as the files analyzed by the tool. Artifact locations can
+	// refer to them by index.
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+
+	// Taxonomies contains the taxonomies, such as CWE, used to
+	// classify the results of the run.
+	Taxonomies []Driver `json:"taxonomies,omitempty"`
+
+	// Graphs contains the graphs shared by the results of the
+	// run.
+	Graphs []Graph `json:"graphs,omitempty"`
+
+	// OriginalURIBaseIDs maps the URI base identifiers used by the
+	// artifact locations of the run to the URIs they referred to
+	// on the machine where the run was performed. See
+	// [Run.ResolveArtifactLocation].
+	OriginalURIBaseIDs map[string]ArtifactLocation `json:"originalUriBaseIds,omitempty"`
+
+	// VersionControlProvenance describes the state of the version
+	// control repositories containing the analyzed artifacts.
+	VersionControlProvenance []VersionControlDetails `json:"versionControlProvenance,omitempty"`
+
+	// Conversion describes how the run was converted into SARIF
+	// from the native output format of the analysis tool. It is
+	// nil if the tool produced SARIF directly.
+	Conversion *Conversion `json:"conversion,omitempty"`
+
+	// ColumnKind specifies the unit in which the tool measures
+	// columns.
+	ColumnKind ColumnKind `json:"columnKind,omitempty"`
+
+	// DefaultEncoding is the name of the character encoding, such
+	// as "utf-8", of the text artifacts that do not specify one.
+	DefaultEncoding string `json:"defaultEncoding,omitempty"`
+
+	// NewlineSequences contains the character sequences that the
+	// tool treats as line terminators. If it is empty, "\r\n"
+	// and "\n" are assumed.
+	NewlineSequences []string `json:"newlineSequences,omitempty"`
+
+	// Language is the [BCP 47] language tag of the localizable
+	// strings of the run. If it is empty, "en-US" is assumed.
+	//
+	// [BCP 47]: https://www.rfc-editor.org/info/bcp47
+	Language string `json:"language,omitempty"`
+
+	// RedactionTokens contains the strings used to replace
+	// sensitive information in the run. See [Run.Redact].
+	RedactionTokens []string `json:"redactionTokens,omitempty"`
+
+	// ExternalPropertyFileReferences contains references to the
+	// external property files holding parts of the run. See
+	// [Log.ResolveExternalProperties].
+	ExternalPropertyFileReferences *ExternalPropertyFileReferences `json:"externalPropertyFileReferences,omitempty"`
+
+	// AutomationDetails identifies the run as part of a series of
+	// runs, such as the nightly analysis of a repository.
+	AutomationDetails *RunAutomationDetails `json:"automationDetails,omitempty"`
+
+	// RunAggregates identifies the aggregates, such as the runs of
+	// all the tools for a given commit, that the run belongs to.
+	RunAggregates []RunAutomationDetails `json:"runAggregates,omitempty"`
+
+	// BaselineGUID is the GUID of the automation details of the
+	// run used as baseline to compute [Result.BaselineState].
+	BaselineGUID string `json:"baselineGuid,omitempty"`
+
+	// ThreadFlowLocations contains thread flow locations shared by
+	// the code flows of the run, which refer to them by index. See
+	// [Run.ResolveThreadFlowLocation].
+	ThreadFlowLocations []ThreadFlowLocation `json:"threadFlowLocations,omitempty"`
+
+	// Addresses contains the addresses shared by the physical
+	// locations of the run, which refer to them by index.
+	Addresses []Address `json:"addresses,omitempty"`
+
+	// Translations contains the translations of the localizable
+	// strings of the tool components of the run. See
+	// [Run.LocalizedRule].
+	Translations []Driver `json:"translations,omitempty"`
+
+	// WebRequests contains the HTTP requests shared by the results
+	// of the run, which refer to them by index.
+	WebRequests []WebRequest `json:"webRequests,omitempty"`
+
+	// WebResponses contains the HTTP responses shared by the
+	// results of the run, which refer to them by index.
+	WebResponses []WebResponse `json:"webResponses,omitempty"`
+
+	// Description describes the run. It is nil if the run has no
+	// description.
+	Description *Description `json:"description,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names. It can be used to store run metadata such as the
+	// pipeline URL or trigger.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// MarshalJSON implements [json.Marshaler]. A non-nil empty
+// [Run.Results] slice is encoded as an empty array, which states that
+// the tool ran and did not detect any result, while a nil slice is
+// omitted, which means that the results are unknown.
+func (run Run) MarshalJSON() ([]byte, error) {
+	type jsonRun Run
+	v := struct {
+		jsonRun
+		Results *[]Result `json:"results,omitempty"`
+	}{jsonRun: jsonRun(run)}
+	if run.Results != nil {
+		v.Results = &run.Results
+	}
+	return json.Marshal(v)
+}
+
+// NewCleanRun returns a [Run] of the provided tool with an empty list
+// of results and a successful invocation. It explicitly states that
+// the tool ran and did not detect any result.
+func NewCleanRun(tool Tool) Run {
+	return Run{
+		Tool:        tool,
+		Invocations: []Invocation{{ExecutionSuccessful: true}},
+		Results:     []Result{},
+	}
+}
+
+// FindArtifact returns the artifact referred to by the provided
+// artifact location. If the location has an index, the artifact at
+// that index is returned. Otherwise, the first artifact with the same
+// URI and base URI identifier is returned.
+func (run Run) FindArtifact(loc ArtifactLocation) (artifact Artifact, found bool) {
+	if loc.Index != nil {
+		if *loc.Index < 0 || *loc.Index >= len(run.Artifacts) {
+			return Artifact{}, false
+		}
+		return run.Artifacts[*loc.Index], true
+	}
+
+	for _, artifact := range run.Artifacts {
+		if artifact.Location.URI == loc.URI && artifact.Location.URIBaseID == loc.URIBaseID {
+			return artifact, true
+		}
+	}
+	return Artifact{}, false
+}
+
+// ResultRule returns the rule that was evaluated to produce the
+// provided result. The rule is looked up in the rules of the driver,
+// first by [Result.RuleIndex] or the index of [Result.Rule], and then
+// by [Result.RuleID] or the identifier of [Result.Rule]. It returns
+// false if the result refers to a rule of a tool component other than
+// the driver.
+func (run Run) ResultRule(r Result) (rule Rule, found bool) {
+	var ref ReportingDescriptorReference
+	if r.Rule != nil {
+		ref = *r.Rule
+	}
+	if !ref.ToolComponent.refersTo(run.Tool.Driver) {
+		return Rule{}, false
+	}
+
+	rules := run.Tool.Driver.Rules
+	idx := r.RuleIndex
+	if idx == nil {
+		idx = ref.Index
+	}
+	if idx != nil {
+		if *idx < 0 || *idx >= len(rules) {
+			return Rule{}, false
+		}
+		return rules[*idx], true
+	}
+
+	id := r.RuleID
+	if id == "" {
+		id = ref.ID
+	}
+	if id == "" {
+		return Rule{}, false
+	}
+	for _, rule := range rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// resultRuleID returns the identifier of the rule that was evaluated
+// to produce the provided result, as resolved by [Run.ResultRule]. If
+// the rule is not found, the identifier referenced by the result is
+// returned.
+func (run Run) resultRuleID(r Result) string {
+	rule, _ := run.ResultRule(r)
+	return r.ruleID(rule)
+}
+
+// RunAutomationDetails identifies a run or a group of runs.
+type RunAutomationDetails struct {
+	// Description describes the role played by the run or the
+	// group of runs. It is nil if there is no description.
+	Description *Description `json:"description,omitempty"`
+
+	// ID is a hierarchical identifier, such as
+	// "nightly/linux/2024-03-01", whose components are separated
+	// by slashes. All the components but the last identify the
+	// series and the last one identifies the instance.
+	ID string `json:"id,omitempty"`
+
+	// GUID is a unique identifier in the form of a GUID.
+	GUID string `json:"guid,omitempty"`
+
+	// CorrelationGUID is a GUID shared by all the instances of
+	// the same series.
+	CorrelationGUID string `json:"correlationGuid,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Conversion describes how a converter transformed the output of an
+// analysis tool into SARIF.
+type Conversion struct {
+	// Tool describes the converter.
+	Tool Tool `json:"tool,omitempty"`
+
+	// Invocation describes the invocation of the converter. It is
+	// nil if it is not known.
+	Invocation *Invocation `json:"invocation,omitempty"`
+
+	// AnalysisToolLogFiles contains the locations of the log files
+	// produced by the analysis tool that were converted.
+	AnalysisToolLogFiles []ArtifactLocation `json:"analysisToolLogFiles,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// VersionControlDetails specifies the state of a version control
+// repository at the time a run was performed.
+type VersionControlDetails struct {
+	// RepositoryURI is the absolute URI of the repository.
+	RepositoryURI string `json:"repositoryUri,omitempty"`
+
+	// RevisionID identifies the analyzed revision, such as a git
+	// commit hash.
+	RevisionID string `json:"revisionId,omitempty"`
+
+	// Branch is the name of the analyzed branch.
+	Branch string `json:"branch,omitempty"`
+
+	// RevisionTag is a tag that has been applied to the analyzed
+	// revision.
+	RevisionTag string `json:"revisionTag,omitempty"`
+
+	// AsOfTimeUTC is the time at which the state of the repository
+	// was determined. It is used when the revision is not known.
+	AsOfTimeUTC *time.Time `json:"asOfTimeUtc,omitempty"`
+
+	// MappedTo is the location in the local file system to which
+	// the root of the repository was mapped. It is nil if it is not
+	// known.
+	MappedTo *ArtifactLocation `json:"mappedTo,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// ResolveThreadFlowLocation returns the thread flow location obtained
+// by combining the provided one with the thread flow location of the
+// run it refers to by index. The members set in tfl take precedence
+// over the ones of the referenced location. If tfl has no index, it
+// is returned unchanged. It returns false if the index is out of
+// range.
+func (run Run) ResolveThreadFlowLocation(tfl ThreadFlowLocation) (resolved ThreadFlowLocation, found bool) {
+	if tfl.Index == nil {
+		return tfl, true
+	}
+	if *tfl.Index < 0 || *tfl.Index >= len(run.ThreadFlowLocations) {
+		return ThreadFlowLocation{}, false
+	}
+
+	resolved = run.ThreadFlowLocations[*tfl.Index]
+	resolved.Index = tfl.Index
+	if tfl.Module != "" {
+		resolved.Module = tfl.Module
+	}
+	if !reflect.ValueOf(tfl.Location).IsZero() {
+		resolved.Location = tfl.Location
+	}
+	if tfl.Importance != "" {
+		resolved.Importance = tfl.Importance
+	}
+	return resolved, true
+}
+
+// Invocation describes the invocation of an analysis tool.
+type Invocation struct {
+	// CommandLine is the command line used to invoke the tool.
+	CommandLine string `json:"commandLine,omitempty"`
+
+	// Arguments contains the arguments passed to the tool, in
+	// order.
+	Arguments []string `json:"arguments,omitempty"`
+
+	// ExitCode is the process exit code of the tool. It is nil if
+	// it is not known.
+	ExitCode *int `json:"exitCode,omitempty"`
+
+	// ExecutionSuccessful specifies whether the tool's execution
+	// completed successfully.
+	ExecutionSuccessful bool `json:"executionSuccessful"`
+
+	// StartTimeUTC is the UTC date and time at which the
+	// invocation started.
+	StartTimeUTC *time.Time `json:"startTimeUtc,omitempty"`
+
+	// EndTimeUTC is the UTC date and time at which the invocation
+	// ended.
+	EndTimeUTC *time.Time `json:"endTimeUtc,omitempty"`
+
+	// WorkingDirectory is the working directory of the
+	// invocation. It is nil if it is not known.
+	WorkingDirectory *ArtifactLocation `json:"workingDirectory,omitempty"`
+
+	// EnvironmentVariables contains the environment variables
+	// associated with the invocation.
+	EnvironmentVariables map[string]string `json:"environmentVariables,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
 }
 
 // Tool describes the analysis tool that was run.
@@ -141,12 +738,113 @@ type Driver struct {
 	// found.
 	InformationURI string `json:"informationUri,omitempty"`
 
+	// GUID is a unique identifier for the tool component in the
+	// form of a GUID.
+	GUID string `json:"guid,omitempty"`
+
+	// FullName is the name of the tool component along with its
+	// version and any other useful identifying information.
+	FullName string `json:"fullName,omitempty"`
+
+	// Organization is the organization or company that produced
+	// the tool component.
+	Organization string `json:"organization,omitempty"`
+
+	// Product is the product or product line of which the tool
+	// component is a part.
+	Product string `json:"product,omitempty"`
+
+	// ProductSuite is the name of the product suite to which the
+	// tool component belongs.
+	ProductSuite string `json:"productSuite,omitempty"`
+
+	// ShortDescription is a brief description of the tool
+	// component. It is nil if there is no short description.
+	ShortDescription *Description `json:"shortDescription,omitempty"`
+
+	// FullDescription is a comprehensive description of the tool
+	// component. It is nil if there is no full description.
+	FullDescription *Description `json:"fullDescription,omitempty"`
+
+	// ReleaseDateUTC is the UTC date on which this version of the
+	// tool component was released.
+	ReleaseDateUTC *time.Time `json:"releaseDateUtc,omitempty"`
+
+	// DownloadURI is the absolute URI from which this version of
+	// the tool component can be downloaded.
+	DownloadURI string `json:"downloadUri,omitempty"`
+
+	// SupportedTaxonomies identifies the taxonomies, such as CWE,
+	// whose taxa the rules of the tool component refer to.
+	SupportedTaxonomies []ToolComponentReference `json:"supportedTaxonomies,omitempty"`
+
 	// Properties are govulncheck run metadata, such as vuln db, Go version, etc.
 	Properties map[string]any `json:"properties,omitempty"`
 
 	// Rules provides information about the analysis rules
 	// supported by the tool component.
 	Rules []Rule `json:"rules,omitempty"`
+
+	// Notifications provides information about the notifications
+	// that can be reported by the tool component. Notification
+	// descriptors share the shape of rules.
+	Notifications []Rule `json:"notifications,omitempty"`
+
+	// Taxa contains the classifications defined by the tool
+	// component when it represents a taxonomy, such as the
+	// weaknesses of CWE. Taxa share the shape of rules.
+	Taxa []Rule `json:"taxa,omitempty"`
+
+	// Language is the [BCP 47] language tag of the localizable
+	// strings of the tool component. If it is empty, "en-US" is
+	// assumed.
+	//
+	// [BCP 47]: https://www.rfc-editor.org/info/bcp47
+	Language string `json:"language,omitempty"`
+
+	// AssociatedComponent identifies the tool component that a
+	// translation translates. It is nil for other tool
+	// components.
+	AssociatedComponent *ToolComponentReference `json:"associatedComponent,omitempty"`
+
+	// TranslationMetadata describes the translation when the tool
+	// component is a translation. It is nil for other tool
+	// components.
+	TranslationMetadata *TranslationMetadata `json:"translationMetadata,omitempty"`
+
+	// GlobalMessageStrings contains the message strings shared by
+	// all the reporting descriptors of the tool component, keyed
+	// by identifier. See [Run.ResolveMessage].
+	GlobalMessageStrings map[string]Description `json:"globalMessageStrings,omitempty"`
+}
+
+// TranslationMetadata describes a translation of a tool component.
+type TranslationMetadata struct {
+	// Name is the name of the translation.
+	Name string `json:"name,omitempty"`
+
+	// FullName is the full name of the translation.
+	FullName string `json:"fullName,omitempty"`
+
+	// ShortDescription is a brief description of the translation.
+	// It is nil if there is no short description.
+	ShortDescription *Description `json:"shortDescription,omitempty"`
+
+	// FullDescription is a comprehensive description of the
+	// translation. It is nil if there is no full description.
+	FullDescription *Description `json:"fullDescription,omitempty"`
+
+	// DownloadURI is the absolute URI from which the translation
+	// can be downloaded.
+	DownloadURI string `json:"downloadUri,omitempty"`
+
+	// InformationURI is the absolute URI at which information
+	// about the translation can be found.
+	InformationURI string `json:"informationUri,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
 }
 
 // Rule contains information that describes a "reporting item"
@@ -157,6 +855,26 @@ type Rule struct {
 	// ID is the rule identifier.
 	ID string `json:"id,omitempty"`
 
+	// DeprecatedIDs contains the identifiers by which the rule was
+	// known in previous versions of the tool.
+	DeprecatedIDs []string `json:"deprecatedIds,omitempty"`
+
+	// GUID is a unique identifier for the rule in the form of a
+	// GUID.
+	GUID string `json:"guid,omitempty"`
+
+	// DeprecatedGUIDs contains the GUIDs by which the rule was
+	// known in previous versions of the tool.
+	DeprecatedGUIDs []string `json:"deprecatedGuids,omitempty"`
+
+	// Name is a human-readable identifier of the rule, such as
+	// "UnusedVariable".
+	Name string `json:"name,omitempty"`
+
+	// DeprecatedNames contains the names by which the rule was
+	// known in previous versions of the tool.
+	DeprecatedNames []string `json:"deprecatedNames,omitempty"`
+
 	// ShortDescription provides a concise description of the
 	// reporting item.
 	ShortDescription Description `json:"shortDescription,omitempty"`
@@ -175,6 +893,112 @@ type Rule struct {
 	// Properties is an unordered set of properties with arbitrary
 	// names.
 	Properties map[string]any `json:"properties,omitempty"`
+
+	// Relationships contains the relationships between the rule
+	// and other reporting descriptors, like the taxa it belongs
+	// to.
+	Relationships []Relationship `json:"relationships,omitempty"`
+
+	// MessageStrings contains the message strings of the rule,
+	// keyed by identifier. See [Run.ResolveMessage].
+	MessageStrings map[string]Description `json:"messageStrings,omitempty"`
+
+	// DefaultConfiguration is the configuration used for the rule
+	// in the absence of an explicit one. It is nil if the tool does
+	// not provide it.
+	DefaultConfiguration *ReportingConfiguration `json:"defaultConfiguration,omitempty"`
+}
+
+// ReportingConfiguration describes how a rule is configured.
+type ReportingConfiguration struct {
+	// Enabled specifies whether the rule is enabled. The rule is
+	// enabled if Enabled is nil.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Level specifies the default severity level of the results
+	// produced by the rule.
+	Level string `json:"level,omitempty"`
+
+	// Rank is the default priority or importance, from 0.0 to
+	// 100.0, of the results produced by the rule. It is nil if
+	// the rank is not known.
+	Rank *float64 `json:"rank,omitempty"`
+
+	// Parameters contains the values of the parameters of the
+	// rule.
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+// IsEnabled reports whether the rule is enabled.
+func (rc ReportingConfiguration) IsEnabled() bool {
+	return rc.Enabled == nil || *rc.Enabled
+}
+
+// ReportingDescriptorReference identifies a reporting descriptor,
+// such as a rule or a taxon.
+type ReportingDescriptorReference struct {
+	// ID is the identifier of the reporting descriptor.
+	ID string `json:"id,omitempty"`
+
+	// Index is the index of the reporting descriptor within the
+	// rules, notifications or taxa of the tool component.
+	Index *int `json:"index,omitempty"`
+
+	// GUID is a unique identifier for the reporting descriptor in
+	// the form of a GUID.
+	GUID string `json:"guid,omitempty"`
+
+	// ToolComponent identifies the tool component that contains
+	// the reporting descriptor. If it is nil, the descriptor is
+	// part of the driver.
+	ToolComponent *ToolComponentReference `json:"toolComponent,omitempty"`
+}
+
+// ToolComponentReference identifies a tool component, such as the
+// driver or a taxonomy.
+type ToolComponentReference struct {
+	// Name is the name of the tool component.
+	Name string `json:"name,omitempty"`
+
+	// Index is the index of the tool component within the
+	// taxonomies or extensions of the run.
+	Index *int `json:"index,omitempty"`
+
+	// GUID is a unique identifier for the tool component in the
+	// form of a GUID.
+	GUID string `json:"guid,omitempty"`
+}
+
+// refersTo reports whether the reference identifies the provided tool
+// component, which is the case if the reference is nil or if its name
+// or, in the absence of name, its GUID match the ones of the tool
+// component. References that only have an index identify a taxonomy
+// or an extension, so they do not refer to the driver.
+func (tc *ToolComponentReference) refersTo(d Driver) bool {
+	switch {
+	case tc == nil:
+		return true
+	case tc.Name != "":
+		return tc.Name == d.Name
+	case tc.GUID != "":
+		return tc.GUID == d.GUID
+	}
+	return false
+}
+
+// Relationship describes the relationship between a reporting
+// descriptor and another reporting descriptor.
+type Relationship struct {
+	// Target identifies the related reporting descriptor.
+	Target ReportingDescriptorReference `json:"target,omitempty"`
+
+	// Kinds specifies the kinds of relationship (e.g.
+	// "superset", "subset", "equal", "relevant").
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Description describes the relationship. It is nil if the
+	// relationship has no description.
+	Description *Description `json:"description,omitempty"`
 }
 
 // Description groups together all available textual formats for a
@@ -186,6 +1010,22 @@ type Description struct {
 	// Markdown contains a formatted message expressed in
 	// GitHub-Flavored Markdown.
 	Markdown string `json:"markdown,omitempty"`
+
+	// ID is the identifier of the message string, declared in the
+	// message strings of the rule or in the global message strings
+	// of the tool component, used to construct the message. See
+	// [Run.ResolveMessage].
+	ID string `json:"id,omitempty"`
+
+	// Arguments contains the values substituted for the
+	// placeholders "{0}", "{1}", etc. of the message string.
+	Arguments []string `json:"arguments,omitempty"`
+}
+
+// isEmpty reports whether the description has no text, markdown or
+// identifier.
+func (d Description) isEmpty() bool {
+	return d.Text == "" && d.Markdown == "" && d.ID == ""
 }
 
 // Result describes a single result detected by an analysis tool.
@@ -194,16 +1034,40 @@ type Result struct {
 	// produce the result.
 	RuleID string `json:"ruleId,omitempty"`
 
-	// Level specifies the severity level of the result.
+	// RuleIndex is the index of the rule that was evaluated to
+	// produce the result within the rules of the driver.
+	RuleIndex *int `json:"ruleIndex,omitempty"`
+
+	// Rule identifies the rule that was evaluated to produce the
+	// result. It is nil if the rule is only identified by RuleID
+	// or RuleIndex. See [Run.ResultRule].
+	Rule *ReportingDescriptorReference `json:"rule,omitempty"`
+
+	// Kind specifies the nature of the result: "pass", "open",
+	// "informational", "notApplicable", "review" or "fail". An
+	// empty kind means "fail".
+	Kind string `json:"kind,omitempty"`
+
+	// Level specifies the severity level of the result. See
+	// [Result.EffectiveLevel].
 	Level string `json:"level,omitempty"`
 
 	// Message describes the result.
 	Message Description `json:"message,omitempty"`
 
+	// Taxa contains the taxonomy items, such as CWE weaknesses,
+	// that classify the result.
+	Taxa []ReportingDescriptorReference `json:"taxa,omitempty"`
+
 	// Locations specifies the locations where the result
 	// occurred.
 	Locations []Location `json:"locations,omitempty"`
 
+	// RelatedLocations contains locations related to the
+	// understanding of the problem, such as the declaration of a
+	// variable that is misused at the result location.
+	RelatedLocations []Location `json:"relatedLocations,omitempty"`
+
 	// CodeFlows is intended for use by analysis tools that
 	// provide execution path details that illustrate a possible
 	// problem in the code.
@@ -213,6 +1077,180 @@ type Result struct {
 	// or collect call stack information in the process of
 	// producing results.
 	Stacks []Stack `json:"stacks,omitempty"`
+
+	// Graphs contains the graphs specific to the result.
+	Graphs []Graph `json:"graphs,omitempty"`
+
+	// GraphTraversals contains paths through the graphs of the
+	// result or the run.
+	GraphTraversals []GraphTraversal `json:"graphTraversals,omitempty"`
+
+	// Fixes contains the fixes proposed by the tool to address
+	// the problem reported by the result.
+	Fixes []Fix `json:"fixes,omitempty"`
+
+	// Fingerprints contains stable identifiers of the result,
+	// keyed by the name of the fingerprint algorithm. They are
+	// used to match the result across runs.
+	Fingerprints map[string]string `json:"fingerprints,omitempty"`
+
+	// PartialFingerprints contains contributions to the
+	// fingerprints of the result, keyed by the name of the
+	// fingerprint component.
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+
+	// BaselineState specifies the state of the result with
+	// respect to a previous baseline run.
+	BaselineState BaselineState `json:"baselineState,omitempty"`
+
+	// Suppressions contains the requests to suppress the result,
+	// such as in-source suppressions or triage decisions.
+	Suppressions []Suppression `json:"suppressions,omitempty"`
+
+	// Attachments contains artifacts relevant to the detection of
+	// the result, such as evidence files produced by the tool.
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// OccurrenceCount is the number of times the result was
+	// observed.
+	OccurrenceCount int `json:"occurrenceCount,omitempty"`
+
+	// WorkItemURIs contains the absolute URIs of the work items,
+	// such as issue tracker tickets, associated with the result.
+	WorkItemURIs []string `json:"workItemUris,omitempty"`
+
+	// HostedViewerURI is an absolute URI at which the result can
+	// be viewed.
+	HostedViewerURI string `json:"hostedViewerUri,omitempty"`
+
+	// GUID is a unique identifier for the result in the form of a
+	// GUID. See [Log.AssignGUIDs].
+	GUID string `json:"guid,omitempty"`
+
+	// CorrelationGUID is a stable identifier, in the form of a
+	// GUID, shared by the results that represent the same
+	// underlying problem across runs. See [Result.StableGUID].
+	CorrelationGUID string `json:"correlationGuid,omitempty"`
+
+	// WebRequest is the HTTP request that led to the result. It is
+	// nil if the result is not related to web traffic.
+	WebRequest *WebRequest `json:"webRequest,omitempty"`
+
+	// WebResponse is the HTTP response that led to the result. It
+	// is nil if the result is not related to web traffic.
+	WebResponse *WebResponse `json:"webResponse,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+
+	// Raw is the original JSON encoding of the result. It is only
+	// set when the result is decoded using [WithRawResults].
+	Raw json.RawMessage `json:"-"`
+}
+
+// EffectiveLevel returns the severity level of the result, applying
+// the defaulting rules of the SARIF specification when [Result.Level]
+// is empty. If the result kind is neither empty nor "fail", it returns
+// "none". Otherwise, it returns the level of the default configuration
+// of the provided rule, which should be the rule that was evaluated to
+// produce the result. See [Run.ResultRule]. If the rule does not
+// specify a level, it returns "warning".
+func (r Result) EffectiveLevel(rule Rule) string {
+	if r.Level != "" {
+		return r.Level
+	}
+	if r.Kind != "" && r.Kind != "fail" {
+		return "none"
+	}
+	if dc := rule.DefaultConfiguration; dc != nil && dc.Level != "" {
+		return dc.Level
+	}
+	return "warning"
+}
+
+// ruleID returns the identifier of the provided rule, which should be
+// the rule that was evaluated to produce the result. If it is empty,
+// it returns [Result.RuleID] or, if also empty, the identifier of
+// [Result.Rule].
+func (r Result) ruleID(rule Rule) string {
+	switch {
+	case rule.ID != "":
+		return rule.ID
+	case r.RuleID != "":
+		return r.RuleID
+	case r.Rule != nil:
+		return r.Rule.ID
+	}
+	return ""
+}
+
+// WebRequest describes an HTTP request.
+type WebRequest struct {
+	// Index is the index of the request within the web requests
+	// of the run that this one inherits from.
+	Index *int `json:"index,omitempty"`
+
+	// Protocol is the request protocol, such as "http".
+	Protocol string `json:"protocol,omitempty"`
+
+	// Version is the version of the protocol, such as "1.1".
+	Version string `json:"version,omitempty"`
+
+	// Target is the target of the request, such as its URL.
+	Target string `json:"target,omitempty"`
+
+	// Method is the HTTP method, such as "GET".
+	Method string `json:"method,omitempty"`
+
+	// Headers contains the request headers.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Parameters contains the request parameters.
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Body is the body of the request. It is nil if the request has no
+	// body.
+	Body *ArtifactContent `json:"body,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// WebResponse describes an HTTP response.
+type WebResponse struct {
+	// Index is the index of the response within the web responses
+	// of the run that this one inherits from.
+	Index *int `json:"index,omitempty"`
+
+	// Protocol is the response protocol, such as "http".
+	Protocol string `json:"protocol,omitempty"`
+
+	// Version is the version of the protocol, such as "1.1".
+	Version string `json:"version,omitempty"`
+
+	// StatusCode is the HTTP status code, such as 200.
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// ReasonPhrase is the reason phrase that accompanies the
+	// status code, such as "OK".
+	ReasonPhrase string `json:"reasonPhrase,omitempty"`
+
+	// Headers contains the response headers.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Body is the body of the response. It is nil if the response has no
+	// body.
+	Body *ArtifactContent `json:"body,omitempty"`
+
+	// NoResponseReceived specifies whether the request did not
+	// receive any response, for instance because it timed out.
+	NoResponseReceived bool `json:"noResponseReceived,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
 }
 
 // CodeFlow describes the progress of one or more programs through one
@@ -235,12 +1273,21 @@ type ThreadFlow struct {
 	// Locations is a list locations visited by the tool in the
 	// course of producing the result.
 	Locations []ThreadFlowLocation `json:"locations,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
 }
 
 // ThreadFlowLocation represents a location visited by an analysis
 // tool in the course of simulating or monitoring the execution of a
 // program.
 type ThreadFlowLocation struct {
+	// Index is the index of the thread flow location within the
+	// thread flow locations of the run that this one inherits
+	// from.
+	Index *int `json:"index,omitempty"`
+
 	// Module is the name of the module that contains the code
 	// location specified by this ThreadFlowLocation value.
 	Module string `json:"module,omitempty"`
@@ -248,6 +1295,12 @@ type ThreadFlowLocation struct {
 	// Location specifies the location to which the
 	// ThreadFlowLocation value refers.
 	Location Location `json:"location,omitempty"`
+
+	// Importance specifies the importance of the location for the
+	// understanding of the code flow. Its value is "essential",
+	// "important" or "unimportant". If it is empty, "important"
+	// is assumed.
+	Importance string `json:"importance,omitempty"`
 }
 
 // Stack describes a single call stack. A call stack is a sequence of
@@ -273,6 +1326,243 @@ type Frame struct {
 	Location Location `json:"location,omitempty"`
 }
 
+// Graph is a network of nodes and directed edges that describes
+// some aspect of the structure of the code, such as a call graph or
+// a data flow graph.
+type Graph struct {
+	// Description describes the graph. It is nil if the graph has
+	// no description.
+	Description *Description `json:"description,omitempty"`
+
+	// Nodes contains the nodes of the graph.
+	Nodes []Node `json:"nodes,omitempty"`
+
+	// Edges contains the edges of the graph.
+	Edges []Edge `json:"edges,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Node represents a node in a graph.
+type Node struct {
+	// ID is the identifier of the node. It is unique within the
+	// graph.
+	ID string `json:"id,omitempty"`
+
+	// Label is a short description of the node. It is nil if the
+	// node has no label.
+	Label *Description `json:"label,omitempty"`
+
+	// Location is the code location associated with the node. It
+	// is nil if the node is not associated with a location.
+	Location *Location `json:"location,omitempty"`
+
+	// Children contains the nested nodes of the node.
+	Children []Node `json:"children,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Edge represents a directed edge in a graph.
+type Edge struct {
+	// ID is the identifier of the edge. It is unique within the
+	// graph.
+	ID string `json:"id,omitempty"`
+
+	// Label is a short description of the edge. It is nil if the
+	// edge has no label.
+	Label *Description `json:"label,omitempty"`
+
+	// SourceNodeID is the identifier of the source node.
+	SourceNodeID string `json:"sourceNodeId,omitempty"`
+
+	// TargetNodeID is the identifier of the target node.
+	TargetNodeID string `json:"targetNodeId,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// GraphTraversal represents a path through a graph.
+type GraphTraversal struct {
+	// RunGraphIndex is the index within Run.Graphs of the
+	// traversed graph. It is nil if ResultGraphIndex is set.
+	RunGraphIndex *int `json:"runGraphIndex,omitempty"`
+
+	// ResultGraphIndex is the index within Result.Graphs of the
+	// traversed graph. It is nil if RunGraphIndex is set.
+	ResultGraphIndex *int `json:"resultGraphIndex,omitempty"`
+
+	// Description describes the graph traversal. It is nil if the
+	// graph traversal has no description.
+	Description *Description `json:"description,omitempty"`
+
+	// InitialState contains the values of relevant expressions
+	// at the start of the traversal that may change during the
+	// traversal.
+	InitialState map[string]Description `json:"initialState,omitempty"`
+
+	// ImmutableState contains the values of relevant expressions
+	// that cannot change during the traversal.
+	ImmutableState map[string]Description `json:"immutableState,omitempty"`
+
+	// EdgeTraversals contains the sequence of edges traversed.
+	EdgeTraversals []EdgeTraversal `json:"edgeTraversals,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// EdgeTraversal represents the traversal of a single edge during a
+// graph traversal.
+type EdgeTraversal struct {
+	// EdgeID is the identifier of the traversed edge.
+	EdgeID string `json:"edgeId,omitempty"`
+
+	// Message is a message relevant to the edge traversal. It is
+	// nil if the edge traversal has no message.
+	Message *Description `json:"message,omitempty"`
+
+	// FinalState contains the values of relevant expressions
+	// after the edge has been traversed.
+	FinalState map[string]Description `json:"finalState,omitempty"`
+
+	// StepOverEdgeCount is the number of edge traversals
+	// necessary to return from a nested graph.
+	StepOverEdgeCount int `json:"stepOverEdgeCount,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Fix represents a proposed fix for the problem reported by a
+// result. It specifies a set of artifacts to modify.
+type Fix struct {
+	// Description describes the proposed fix. It is nil if the fix
+	// has no description.
+	Description *Description `json:"description,omitempty"`
+
+	// ArtifactChanges specifies the changes to apply to the
+	// artifacts.
+	ArtifactChanges []ArtifactChange `json:"artifactChanges,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// ArtifactChange represents a change to a single artifact.
+type ArtifactChange struct {
+	// ArtifactLocation is the location of the artifact to change.
+	ArtifactLocation ArtifactLocation `json:"artifactLocation,omitempty"`
+
+	// Replacements specifies the replacements to apply to the
+	// artifact.
+	Replacements []Replacement `json:"replacements,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Replacement represents the replacement of a single region of an
+// artifact.
+type Replacement struct {
+	// DeletedRegion is the region of the artifact to delete. If
+	// the region is empty, InsertedContent is inserted at the
+	// start of the region without deleting anything.
+	DeletedRegion Region `json:"deletedRegion,omitempty"`
+
+	// InsertedContent is the content to insert at the location
+	// specified by DeletedRegion. It is nil if the replacement
+	// only deletes content.
+	InsertedContent *ArtifactContent `json:"insertedContent,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// BaselineState describes the state of a result with respect to a
+// baseline run.
+type BaselineState string
+
+// Baseline states.
+const (
+	// BaselineStateNew means that the result was detected in the
+	// current run but not in the baseline run.
+	BaselineStateNew BaselineState = "new"
+
+	// BaselineStateUnchanged means that the result was detected
+	// in both runs and did not change.
+	BaselineStateUnchanged BaselineState = "unchanged"
+
+	// BaselineStateUpdated means that the result was detected in
+	// both runs but some of its properties changed.
+	BaselineStateUpdated BaselineState = "updated"
+
+	// BaselineStateAbsent means that the result was detected in
+	// the baseline run but not in the current run.
+	BaselineStateAbsent BaselineState = "absent"
+)
+
+// Suppression describes a request to suppress a result.
+type Suppression struct {
+	// Kind specifies the kind of suppression: "inSource" or
+	// "external".
+	Kind string `json:"kind,omitempty"`
+
+	// Status specifies the state of the suppression:
+	// "accepted", "underReview" or "rejected".
+	Status string `json:"status,omitempty"`
+
+	// Justification is the reason given for the suppression.
+	Justification string `json:"justification,omitempty"`
+
+	// GUID is a unique identifier for the suppression in the
+	// form of a GUID.
+	GUID string `json:"guid,omitempty"`
+
+	// Location is the location of the suppression, such as the
+	// source code comment that suppresses the result. It is nil if
+	// the suppression has no location.
+	Location *Location `json:"location,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Attachment is an artifact relevant to the detection of a result.
+type Attachment struct {
+	// Description describes the attachment. It is nil if the
+	// attachment has no description.
+	Description *Description `json:"description,omitempty"`
+
+	// ArtifactLocation is the location of the attachment. It is
+	// nil if the location is not known.
+	ArtifactLocation *ArtifactLocation `json:"artifactLocation,omitempty"`
+
+	// Regions contains the regions of interest within the
+	// attachment.
+	Regions []Region `json:"regions,omitempty"`
+
+	// Rectangles contains the areas of interest within the
+	// attachment, if it is an image.
+	Rectangles []Rectangle `json:"rectangles,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
 // Location describes a location.
 type Location struct {
 	// PhysicalLocation identifies the file within which the
@@ -291,14 +1581,66 @@ type PhysicalLocation struct {
 
 	// Region represents a relevant portion of the artifact.
 	Region Region `json:"region,omitempty"`
+
+	// ContextRegion is a superset of Region intended to provide
+	// the viewer with context about the result. It is nil if there
+	// is no context region.
+	ContextRegion *Region `json:"contextRegion,omitempty"`
+
+	// Address is the address of the location in a binary
+	// artifact or in memory. It is nil if the location is not
+	// identified by address.
+	Address *Address `json:"address,omitempty"`
+}
+
+// Address is the address of a location in a binary artifact or in the
+// address space of a process. Addresses can be absolute or relative to
+// a parent address, forming a hierarchy such as module, section and
+// function.
+type Address struct {
+	// AbsoluteAddress is the address of the location. It is nil if
+	// it is not known.
+	AbsoluteAddress *int64 `json:"absoluteAddress,omitempty"`
+
+	// RelativeAddress is the address of the location relative to
+	// the absolute address of the top-most parent address.
+	RelativeAddress *int64 `json:"relativeAddress,omitempty"`
+
+	// OffsetFromParent is the address of the location relative to
+	// the parent address.
+	OffsetFromParent *int64 `json:"offsetFromParent,omitempty"`
+
+	// Length is the number of bytes of the addressable region.
+	Length *int64 `json:"length,omitempty"`
+
+	// Kind is the kind of addressable region, such as "module",
+	// "section", "function" or "instruction".
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the addressable region, such as the
+	// name of a section.
+	Name string `json:"name,omitempty"`
+
+	// FullyQualifiedName is the name of the addressable region
+	// qualified by the names of its parents, such as
+	// "app.exe!.text".
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+
+	// Index is the index of the address within the addresses of
+	// the run that this one inherits from.
+	Index *int `json:"index,omitempty"`
+
+	// ParentIndex is the index of the parent address within the
+	// addresses of the run.
+	ParentIndex *int `json:"parentIndex,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
 }
 
 // String returns the string representation of the physical location.
 func (loc PhysicalLocation) String() string {
-	if (loc == PhysicalLocation{}) {
-		return ""
-	}
-
 	s := path.Join(loc.ArtifactLocation.URIBaseID, loc.ArtifactLocation.URI)
 	if loc.Region.StartLine != 0 {
 		s += fmt.Sprintf(":%v", loc.Region.StartLine)
@@ -324,6 +1666,11 @@ type ArtifactLocation struct {
 
 	// URIBaseID describes a top-level artifact.
 	URIBaseID string `json:"uriBaseId,omitempty"`
+
+	// Index is the index within Run.Artifacts of the artifact
+	// object associated with the artifact location. It is nil if
+	// there is no associated artifact object.
+	Index *int `json:"index,omitempty"`
 }
 
 // Region represents a contiguous portion of an artifact.
@@ -343,4 +1690,95 @@ type Region struct {
 	// EndColumn is the column number of the last character in the
 	// region.
 	EndColumn int `json:"endColumn,omitempty"`
+
+	// Snippet contains a portion of the artifact that includes
+	// the region. It is nil if there is no snippet.
+	Snippet *ArtifactContent `json:"snippet,omitempty"`
+}
+
+// Rectangle specifies a rectangular area within an image. Its
+// coordinates are measured in pixels. Coordinates are nil if they are
+// not known.
+type Rectangle struct {
+	// Top is the Y coordinate of the top edge of the rectangle.
+	Top *float64 `json:"top,omitempty"`
+
+	// Left is the X coordinate of the left edge of the rectangle.
+	Left *float64 `json:"left,omitempty"`
+
+	// Bottom is the Y coordinate of the bottom edge of the
+	// rectangle.
+	Bottom *float64 `json:"bottom,omitempty"`
+
+	// Right is the X coordinate of the right edge of the
+	// rectangle.
+	Right *float64 `json:"right,omitempty"`
+
+	// Message is a message relevant to the rectangle. It is nil if
+	// the rectangle has no message.
+	Message *Description `json:"message,omitempty"`
+}
+
+// Artifact represents a single artifact, such as a source file.
+type Artifact struct {
+	// Location is the location of the artifact.
+	Location ArtifactLocation `json:"location,omitempty"`
+
+	// ParentIndex is the index within Run.Artifacts of the
+	// artifact containing this artifact, like an archive
+	// containing a file. It is nil if the artifact is not
+	// contained in another artifact.
+	ParentIndex *int `json:"parentIndex,omitempty"`
+
+	// Length is the length of the artifact in bytes. It is nil if
+	// the length is not known.
+	Length *int `json:"length,omitempty"`
+
+	// MimeType is the MIME type of the artifact.
+	MimeType string `json:"mimeType,omitempty"`
+
+	// Hashes is a dictionary, each of whose keys is the name of
+	// a hash function and each of whose values is the hashed
+	// value of the artifact produced by the specified hash
+	// function.
+	Hashes map[string]string `json:"hashes,omitempty"`
+
+	// Roles specifies the roles played by the artifact in the
+	// analysis (e.g. "analysisTarget", "resultFile").
+	Roles []string `json:"roles,omitempty"`
+
+	// SourceLanguage specifies the programming language in which
+	// the artifact is written.
+	SourceLanguage string `json:"sourceLanguage,omitempty"`
+
+	// Contents contains the contents of the artifact. It is nil if
+	// the contents are not embedded in the log.
+	Contents *ArtifactContent `json:"contents,omitempty"`
+
+	// Encoding specifies the encoding of a textual artifact.
+	Encoding string `json:"encoding,omitempty"`
+
+	// Description describes the artifact. It is nil if the
+	// artifact has no description.
+	Description *Description `json:"description,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// ArtifactContent represents the contents of an artifact or a portion
+// of it.
+type ArtifactContent struct {
+	// Text is the UTF-8 encoded content of a textual artifact.
+	Text string `json:"text,omitempty"`
+
+	// Binary is the MIME Base64-encoded content of a binary
+	// artifact, or of a textual artifact in its original
+	// encoding.
+	Binary string `json:"binary,omitempty"`
+
+	// Properties is an unordered set of properties with arbitrary
+	// names. See [Log.TruncateContents].
+	Properties map[string]any `json:"properties,omitempty"`
 }
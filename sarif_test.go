@@ -3,8 +3,11 @@
 package sarif
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -79,6 +82,206 @@ func TestDecodeFile(t *testing.T) {
 	}
 }
 
+func TestDecodeFileRawResults(t *testing.T) {
+	l, err := DecodeFile("testdata/govulncheck.json", WithRawResults())
+	if err != nil {
+		t.Fatalf("could not decode SARIF file: %v", err)
+	}
+
+	for _, run := range l.Runs {
+		for _, result := range run.Results {
+			if result.Raw == nil {
+				t.Fatalf("missing raw result: %v", result.RuleID)
+			}
+
+			var got Result
+			if err := json.Unmarshal(result.Raw, &got); err != nil {
+				t.Fatalf("could not decode raw result: %v", err)
+			}
+			got.Raw = result.Raw
+			if diff := cmp.Diff(result, got); diff != "" {
+				t.Errorf("result mismatch (-want +got):\n%v", diff)
+			}
+		}
+	}
+
+	l, err = DecodeFile("testdata/govulncheck.json")
+	if err != nil {
+		t.Fatalf("could not decode SARIF file: %v", err)
+	}
+	if raw := l.Runs[0].Results[0].Raw; raw != nil {
+		t.Errorf("unexpected raw result: %s", raw)
+	}
+}
+
+func TestDecodeOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		doc        string
+		opts       []DecodeOption
+		wantNilErr bool
+	}{
+		{
+			name:       "no options",
+			doc:        `{"version": "2.1.0", "foo": 1}`,
+			opts:       nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "strict",
+			doc:        `{"version": "2.1.0", "foo": 1}`,
+			opts:       []DecodeOption{WithStrict()},
+			wantNilErr: false,
+		},
+		{
+			name:       "strict raw results",
+			doc:        `{"version": "2.1.0", "foo": 1}`,
+			opts:       []DecodeOption{WithStrict(), WithRawResults()},
+			wantNilErr: false,
+		},
+		{
+			name:       "max size",
+			doc:        `{"version": "2.1.0"}`,
+			opts:       []DecodeOption{WithMaxSize(20)},
+			wantNilErr: true,
+		},
+		{
+			name:       "max size exceeded",
+			doc:        `{"version": "2.1.0"}`,
+			opts:       []DecodeOption{WithMaxSize(19)},
+			wantNilErr: false,
+		},
+		{
+			name:       "version",
+			doc:        `{"version": "3.1.0"}`,
+			opts:       nil,
+			wantNilErr: false,
+		},
+		{
+			name:       "lax version",
+			doc:        `{"version": "3.1.0"}`,
+			opts:       []DecodeOption{WithLaxVersion()},
+			wantNilErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tt.doc), tt.opts...)
+			if (err == nil) != tt.wantNilErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	data, err := os.ReadFile("testdata/roundtrip.json")
+	if err != nil {
+		t.Fatalf("could not read SARIF file: %v", err)
+	}
+
+	l, err := Decode(bytes.NewReader(data), WithStrict())
+	if err != nil {
+		t.Fatalf("could not decode SARIF document: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := l.Encode(buf); err != nil {
+		t.Fatalf("could not encode SARIF document: %v", err)
+	}
+
+	var want, got any
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatalf("could not decode JSON document: %v", err)
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("could not decode encoded document: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("document mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestDecodeAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantNames  []string
+		wantNilErr bool
+	}{
+		{
+			name:       "single",
+			input:      `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "a"}}}]}`,
+			wantNames:  []string{"a"},
+			wantNilErr: true,
+		},
+		{
+			name: "concatenated",
+			input: `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "a"}}}]}
+				{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "b"}}}]}`,
+			wantNames:  []string{"a", "b"},
+			wantNilErr: true,
+		},
+		{
+			name: "array",
+			input: `[
+				{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "a"}}}]},
+				{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "b"}}}]}
+			]`,
+			wantNames:  []string{"a", "b"},
+			wantNilErr: true,
+		},
+		{
+			name: "mixed",
+			input: `[{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "a"}}}]}]
+				{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "b"}}}]}`,
+			wantNames:  []string{"a", "b"},
+			wantNilErr: true,
+		},
+		{
+			name:       "empty",
+			input:      "",
+			wantNames:  nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "invalid version",
+			input:      `{"version": "2.1.0"} {"version": "3.1.0"}`,
+			wantNilErr: false,
+		},
+		{
+			name:       "malformed",
+			input:      `{"version": "2.1.0"} {`,
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, err := DecodeAll(strings.NewReader(tt.input))
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			var names []string
+			for _, l := range logs {
+				names = append(names, l.Runs[0].Tool.Driver.Name)
+			}
+			if diff := cmp.Diff(tt.wantNames, names); diff != "" {
+				t.Errorf("names mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
 func TestEncodeFile(t *testing.T) {
 	tmpdir, err := os.MkdirTemp("", "sarif")
 	if err != nil {
@@ -162,6 +365,126 @@ func TestEncodeFile(t *testing.T) {
 	}
 }
 
+func TestLog_Encode(t *testing.T) {
+	const testSchema = "https://example.org/sarif-2.1.0.json"
+
+	tests := []struct {
+		name string
+		l    Log
+		opts []EncodeOption
+		want string
+	}{
+		{
+			name: "no options",
+			l:    Log{},
+			opts: nil,
+			want: "{\n  \"version\": \"2.1.0\",\n  \"$schema\": \"" + sarifSchema + "\"\n}\n",
+		},
+		{
+			name: "indent",
+			l:    Log{},
+			opts: []EncodeOption{WithIndent("\t")},
+			want: "{\n\t\"version\": \"2.1.0\",\n\t\"$schema\": \"" + sarifSchema + "\"\n}\n",
+		},
+		{
+			name: "compact schema URI",
+			l:    Log{},
+			opts: []EncodeOption{WithIndent(""), WithSchemaURI(testSchema)},
+			want: `{"version":"2.1.0","$schema":"` + testSchema + `"}` + "\n",
+		},
+		{
+			name: "log schema",
+			l:    Log{Schema: sarifSchema},
+			opts: []EncodeOption{WithIndent(""), WithSchemaURI(testSchema)},
+			want: `{"version":"2.1.0","$schema":"` + sarifSchema + `"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := tt.l.Encode(buf, tt.opts...); err != nil {
+				t.Fatalf("encode error: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("document mismatch: want: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewCleanLog(t *testing.T) {
+	l := NewCleanLog(Tool{Driver: Driver{Name: "linter"}})
+
+	buf := &bytes.Buffer{}
+	if err := l.Encode(buf); err != nil {
+		t.Fatalf("could not encode SARIF document: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"results": []`) {
+		t.Errorf("missing empty results array:\n%v", buf)
+	}
+
+	got, err := Decode(buf)
+	if err != nil {
+		t.Fatalf("could not decode SARIF document: %v", err)
+	}
+	if !got.IsClean() {
+		t.Errorf("decoded log is not clean")
+	}
+	if name := got.Runs[0].Tool.Driver.Name; name != "linter" {
+		t.Errorf("tool name mismatch: got: %v", name)
+	}
+}
+
+func TestLog_IsClean(t *testing.T) {
+	tests := []struct {
+		name string
+		l    Log
+		want bool
+	}{
+		{
+			name: "clean",
+			l:    NewCleanLog(Tool{}),
+			want: true,
+		},
+		{
+			name: "no runs",
+			l:    Log{},
+			want: false,
+		},
+		{
+			name: "unknown results",
+			l:    Log{Runs: []Run{{}}},
+			want: false,
+		},
+		{
+			name: "results",
+			l:    Log{Runs: []Run{{Results: []Result{{RuleID: "R1"}}}}},
+			want: false,
+		},
+		{
+			name: "failed invocation",
+			l: Log{
+				Runs: []Run{
+					{
+						Invocations: []Invocation{{ExecutionSuccessful: false}},
+						Results:     []Result{},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.l.IsClean(); got != tt.want {
+				t.Errorf("clean mismatch: want: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestLog_FindRule(t *testing.T) {
 	l := Log{
 		Runs: []Run{
@@ -176,7 +499,8 @@ func TestLog_FindRule(t *testing.T) {
 								},
 							},
 							{
-								ID: "id-2",
+								ID:            "id-2",
+								DeprecatedIDs: []string{"old-id-2", "id-1"},
 								ShortDescription: Description{
 									Text: "description 2",
 								},
@@ -198,13 +522,37 @@ func TestLog_FindRule(t *testing.T) {
 			name: "found",
 			id:   "id-2",
 			wantRule: Rule{
-				ID: "id-2",
+				ID:            "id-2",
+				DeprecatedIDs: []string{"old-id-2", "id-1"},
+				ShortDescription: Description{
+					Text: "description 2",
+				},
+			},
+			wantFound: true,
+		},
+		{
+			name: "deprecated ID",
+			id:   "old-id-2",
+			wantRule: Rule{
+				ID:            "id-2",
+				DeprecatedIDs: []string{"old-id-2", "id-1"},
 				ShortDescription: Description{
 					Text: "description 2",
 				},
 			},
 			wantFound: true,
 		},
+		{
+			name: "current ID takes precedence",
+			id:   "id-1",
+			wantRule: Rule{
+				ID: "id-1",
+				ShortDescription: Description{
+					Text: "description 1",
+				},
+			},
+			wantFound: true,
+		},
 		{
 			name:      "not found",
 			id:        "id-3",
@@ -226,6 +574,436 @@ func TestLog_FindRule(t *testing.T) {
 	}
 }
 
+func TestLog_FindNotificationDescriptor(t *testing.T) {
+	l := Log{
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Rules: []Rule{
+							{
+								ID: "id-1",
+							},
+						},
+						Notifications: []Rule{
+							{
+								ID: "id-2",
+								ShortDescription: Description{
+									Text: "description 2",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		id             string
+		wantDescriptor Rule
+		wantFound      bool
+	}{
+		{
+			name: "found",
+			id:   "id-2",
+			wantDescriptor: Rule{
+				ID: "id-2",
+				ShortDescription: Description{
+					Text: "description 2",
+				},
+			},
+			wantFound: true,
+		},
+		{
+			name:           "rule",
+			id:             "id-1",
+			wantDescriptor: Rule{},
+			wantFound:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			descriptor, found := l.FindNotificationDescriptor(tt.id)
+			if diff := cmp.Diff(tt.wantDescriptor, descriptor); diff != "" {
+				t.Errorf("descriptor mismatch (-want +got):\n%v", diff)
+			}
+			if found != tt.wantFound {
+				t.Errorf("found mismatch: want: %v, got: %v", tt.wantFound, found)
+			}
+		})
+	}
+}
+
+func TestRun_FindArtifact(t *testing.T) {
+	run := Run{
+		Artifacts: []Artifact{
+			{
+				Location: ArtifactLocation{URI: "a.go", URIBaseID: "SRCROOT"},
+			},
+			{
+				Location: ArtifactLocation{URI: "b.go", URIBaseID: "SRCROOT"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		loc       ArtifactLocation
+		wantURI   string
+		wantFound bool
+	}{
+		{
+			name:      "index",
+			loc:       ArtifactLocation{Index: ptr(1)},
+			wantURI:   "b.go",
+			wantFound: true,
+		},
+		{
+			name:      "index zero",
+			loc:       ArtifactLocation{URI: "b.go", Index: ptr(0)},
+			wantURI:   "a.go",
+			wantFound: true,
+		},
+		{
+			name:      "uri",
+			loc:       ArtifactLocation{URI: "b.go", URIBaseID: "SRCROOT"},
+			wantURI:   "b.go",
+			wantFound: true,
+		},
+		{
+			name:      "index out of range",
+			loc:       ArtifactLocation{Index: ptr(2)},
+			wantFound: false,
+		},
+		{
+			name:      "base mismatch",
+			loc:       ArtifactLocation{URI: "b.go"},
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifact, found := run.FindArtifact(tt.loc)
+			if found != tt.wantFound {
+				t.Fatalf("found mismatch: want: %v, got: %v", tt.wantFound, found)
+			}
+			if artifact.Location.URI != tt.wantURI {
+				t.Errorf("artifact mismatch: want URI: %v, got: %v", tt.wantURI, artifact.Location.URI)
+			}
+		})
+	}
+}
+
+func TestRun_ResultRule(t *testing.T) {
+	run := Run{
+		Tool: Tool{
+			Driver: Driver{
+				Name: "linter",
+				GUID: "c0ffee00-0000-4000-8000-000000000000",
+				Rules: []Rule{
+					{ID: "R0"},
+					{ID: "R1"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		result    Result
+		wantID    string
+		wantFound bool
+	}{
+		{
+			name:      "rule ID",
+			result:    Result{RuleID: "R1"},
+			wantID:    "R1",
+			wantFound: true,
+		},
+		{
+			name:      "rule index zero",
+			result:    Result{RuleIndex: ptr(0)},
+			wantID:    "R0",
+			wantFound: true,
+		},
+		{
+			name:      "index takes precedence",
+			result:    Result{RuleID: "R0", RuleIndex: ptr(1)},
+			wantID:    "R1",
+			wantFound: true,
+		},
+		{
+			name:      "reference index",
+			result:    Result{Rule: &ReportingDescriptorReference{Index: ptr(1)}},
+			wantID:    "R1",
+			wantFound: true,
+		},
+		{
+			name:      "reference ID",
+			result:    Result{Rule: &ReportingDescriptorReference{ID: "R0"}},
+			wantID:    "R0",
+			wantFound: true,
+		},
+		{
+			name: "driver reference",
+			result: Result{
+				Rule: &ReportingDescriptorReference{
+					ID:            "R1",
+					ToolComponent: &ToolComponentReference{Name: "linter"},
+				},
+			},
+			wantID:    "R1",
+			wantFound: true,
+		},
+		{
+			name: "driver GUID reference",
+			result: Result{
+				Rule: &ReportingDescriptorReference{
+					ID:            "R1",
+					ToolComponent: &ToolComponentReference{GUID: "c0ffee00-0000-4000-8000-000000000000"},
+				},
+			},
+			wantID:    "R1",
+			wantFound: true,
+		},
+		{
+			name: "extension index reference",
+			result: Result{
+				Rule: &ReportingDescriptorReference{
+					ID:            "R1",
+					ToolComponent: &ToolComponentReference{Index: ptr(0)},
+				},
+			},
+			wantFound: false,
+		},
+		{
+			name: "other tool component",
+			result: Result{
+				Rule: &ReportingDescriptorReference{
+					ID:            "R1",
+					ToolComponent: &ToolComponentReference{Name: "plugin"},
+				},
+			},
+			wantFound: false,
+		},
+		{
+			name:      "index out of range",
+			result:    Result{RuleIndex: ptr(2)},
+			wantFound: false,
+		},
+		{
+			name:      "unknown ID",
+			result:    Result{RuleID: "R2"},
+			wantFound: false,
+		},
+		{
+			name:      "no rule",
+			result:    Result{},
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, found := run.ResultRule(tt.result)
+			if found != tt.wantFound {
+				t.Fatalf("found mismatch: want: %v, got: %v", tt.wantFound, found)
+			}
+			if rule.ID != tt.wantID {
+				t.Errorf("rule mismatch: want: %v, got: %v", tt.wantID, rule.ID)
+			}
+		})
+	}
+}
+
+func TestResult_EffectiveLevel(t *testing.T) {
+	errorRule := Rule{
+		ID:                   "R0",
+		DefaultConfiguration: &ReportingConfiguration{Level: "error"},
+	}
+
+	tests := []struct {
+		name   string
+		result Result
+		rule   Rule
+		want   string
+	}{
+		{
+			name:   "explicit level",
+			result: Result{Level: "note"},
+			rule:   errorRule,
+			want:   "note",
+		},
+		{
+			name:   "rule default level",
+			result: Result{},
+			rule:   errorRule,
+			want:   "error",
+		},
+		{
+			name:   "fail kind",
+			result: Result{Kind: "fail"},
+			rule:   errorRule,
+			want:   "error",
+		},
+		{
+			name:   "non-fail kind",
+			result: Result{Kind: "pass"},
+			rule:   errorRule,
+			want:   "none",
+		},
+		{
+			name:   "non-fail kind with explicit level",
+			result: Result{Kind: "review", Level: "warning"},
+			rule:   errorRule,
+			want:   "warning",
+		},
+		{
+			name:   "rule without default configuration",
+			result: Result{},
+			rule:   Rule{ID: "R0"},
+			want:   "warning",
+		},
+		{
+			name:   "default configuration without level",
+			result: Result{},
+			rule:   Rule{ID: "R0", DefaultConfiguration: &ReportingConfiguration{}},
+			want:   "warning",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.EffectiveLevel(tt.rule); got != tt.want {
+				t.Errorf("level mismatch: want: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReportingConfiguration_IsEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		rc   ReportingConfiguration
+		want bool
+	}{
+		{
+			name: "default",
+			rc:   ReportingConfiguration{},
+			want: true,
+		},
+		{
+			name: "enabled",
+			rc:   ReportingConfiguration{Enabled: ptr(true)},
+			want: true,
+		},
+		{
+			name: "disabled",
+			rc:   ReportingConfiguration{Enabled: ptr(false)},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rc.IsEnabled(); got != tt.want {
+				t.Errorf("enabled mismatch: want: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRun_ResolveThreadFlowLocation(t *testing.T) {
+	run := Run{
+		ThreadFlowLocations: []ThreadFlowLocation{
+			{Module: "main", Location: Location{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: "a.go"},
+					Region:           Region{StartLine: 1},
+				},
+			}, Importance: "essential"},
+			{Location: Location{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: "b.go"},
+					Region:           Region{StartLine: 2},
+				},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		tfl       ThreadFlowLocation
+		want      ThreadFlowLocation
+		wantFound bool
+	}{
+		{
+			name: "no index",
+			tfl: ThreadFlowLocation{Location: Location{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: "c.go"},
+					Region:           Region{StartLine: 3},
+				},
+			}},
+			want: ThreadFlowLocation{Location: Location{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: "c.go"},
+					Region:           Region{StartLine: 3},
+				},
+			}},
+			wantFound: true,
+		},
+		{
+			name: "index zero",
+			tfl:  ThreadFlowLocation{Index: ptr(0)},
+			want: ThreadFlowLocation{Index: ptr(0), Module: "main", Location: Location{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: "a.go"},
+					Region:           Region{StartLine: 1},
+				},
+			}, Importance: "essential"},
+			wantFound: true,
+		},
+		{
+			name: "overrides",
+			tfl: ThreadFlowLocation{Index: ptr(0), Location: Location{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: "c.go"},
+					Region:           Region{StartLine: 3},
+				},
+			}, Importance: "unimportant"},
+			want: ThreadFlowLocation{Index: ptr(0), Module: "main", Location: Location{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: "c.go"},
+					Region:           Region{StartLine: 3},
+				},
+			}, Importance: "unimportant"},
+			wantFound: true,
+		},
+		{
+			name:      "index out of range",
+			tfl:       ThreadFlowLocation{Index: ptr(2)},
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := run.ResolveThreadFlowLocation(tt.tfl)
+			if found != tt.wantFound {
+				t.Fatalf("found mismatch: want: %v, got: %v", tt.wantFound, found)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("thread flow location mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
 func TestPhysicalLocation_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -373,3 +1151,8 @@ func TestPhysicalLocation_String(t *testing.T) {
 		})
 	}
 }
+
+// ptr returns a pointer to a copy of v.
+func ptr[T any](v T) *T {
+	return &v
+}
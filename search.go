@@ -0,0 +1,166 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Searchable fields. They can be used in search queries to restrict
+// the matching of a term to a specific field (e.g. "rule:injection").
+const (
+	// SearchFieldMessage is the message of the result.
+	SearchFieldMessage = "message"
+
+	// SearchFieldRule contains the identifier and the short,
+	// full and help descriptions of the rule of the result.
+	SearchFieldRule = "rule"
+
+	// SearchFieldLevel is the level of the result.
+	SearchFieldLevel = "level"
+
+	// SearchFieldFile contains the artifact locations of the
+	// result.
+	SearchFieldFile = "file"
+)
+
+// searchFields is the list of supported search fields.
+var searchFields = []string{SearchFieldMessage, SearchFieldRule, SearchFieldLevel, SearchFieldFile}
+
+// SearchHit is a result matched by a search query.
+type SearchHit struct {
+	// RunIndex is the index of the run containing the result.
+	RunIndex int
+
+	// ResultIndex is the index of the result within the run.
+	ResultIndex int
+
+	// Result is the matched result.
+	Result Result
+}
+
+// SearchIndex is an inverted index over the text of the results of a
+// [Log]. It must be created with [NewSearchIndex].
+type SearchIndex struct {
+	hits     []SearchHit
+	postings map[string]map[string][]int
+}
+
+// NewSearchIndex builds a [SearchIndex] over the results of the
+// provided [Log].
+func NewSearchIndex(l Log) *SearchIndex {
+	idx := &SearchIndex{postings: make(map[string]map[string][]int)}
+	for _, field := range searchFields {
+		idx.postings[field] = make(map[string][]int)
+	}
+
+	for i, run := range l.Runs {
+		for j, result := range run.Results {
+			doc := len(idx.hits)
+			idx.hits = append(idx.hits, SearchHit{RunIndex: i, ResultIndex: j, Result: result})
+
+			idx.add(SearchFieldMessage, doc, result.Message.Text, result.Message.Markdown)
+			idx.add(SearchFieldLevel, doc, result.Level)
+			for _, loc := range result.Locations {
+				idx.add(SearchFieldFile, doc, loc.PhysicalLocation.ArtifactLocation.URI)
+			}
+
+			idx.add(SearchFieldRule, doc, run.resultRuleID(result))
+			if rule, found := run.ResultRule(result); found {
+				idx.add(SearchFieldRule, doc,
+					rule.ShortDescription.Text, rule.ShortDescription.Markdown,
+					rule.FullDescription.Text, rule.FullDescription.Markdown,
+					rule.Help.Text, rule.Help.Markdown,
+				)
+			}
+		}
+	}
+	return idx
+}
+
+// add indexes the terms in texts under the specified field for the
+// provided document.
+func (idx *SearchIndex) add(field string, doc int, texts ...string) {
+	for _, text := range texts {
+		for _, term := range searchTerms(text) {
+			docs := idx.postings[field][term]
+			if len(docs) > 0 && docs[len(docs)-1] == doc {
+				continue
+			}
+			idx.postings[field][term] = append(docs, doc)
+		}
+	}
+}
+
+// Search returns the results matching all the terms of the provided
+// query. A query is a list of space-separated terms. A term can be
+// restricted to a specific field using the syntax "field:term".
+// Otherwise, it can match any field. Matching is case-insensitive.
+// The hits are returned in the order in which the results appear in
+// the log.
+func (idx *SearchIndex) Search(query string) ([]SearchHit, error) {
+	var docs []int
+	first := true
+	for _, qt := range strings.Fields(query) {
+		fields := searchFields
+		if field, term, found := strings.Cut(qt, ":"); found {
+			if !slices.Contains(searchFields, field) {
+				return nil, fmt.Errorf("unknown search field: %v", field)
+			}
+			fields = []string{field}
+			qt = term
+		}
+
+		for _, term := range searchTerms(qt) {
+			var matches []int
+			for _, field := range fields {
+				matches = append(matches, idx.postings[field][term]...)
+			}
+			slices.Sort(matches)
+			matches = slices.Compact(matches)
+
+			if first {
+				docs = matches
+				first = false
+			} else {
+				docs = intersect(docs, matches)
+			}
+		}
+	}
+
+	var hits []SearchHit
+	for _, doc := range docs {
+		hits = append(hits, idx.hits[doc])
+	}
+	return hits, nil
+}
+
+// searchTerms splits the provided text into lowercase terms. Terms
+// are sequences of letters and digits.
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// intersect returns the elements present in both a and b, which must
+// be sorted.
+func intersect(a, b []int) []int {
+	var s []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			s = append(s, a[i])
+			i++
+			j++
+		}
+	}
+	return s
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSearchIndex_Search(t *testing.T) {
+	l := Log{
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Rules: []Rule{
+							{
+								ID:               "SQLI",
+								ShortDescription: Description{Text: "SQL injection"},
+							},
+							{
+								ID:               "XSS",
+								ShortDescription: Description{Text: "Cross-site scripting"},
+							},
+						},
+					},
+				},
+				Results: []Result{
+					{
+						RuleID:  "SQLI",
+						Level:   "error",
+						Message: Description{Text: "Query built from user input."},
+						Locations: []Location{
+							{PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: "src/payments/db.go"}}},
+						},
+					},
+					{
+						RuleIndex: ptr(1),
+						Level:     "warning",
+						Message:   Description{Text: "Unescaped user input."},
+						Locations: []Location{
+							{PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: "src/payments/web.go"}}},
+						},
+					},
+				},
+			},
+			{
+				Results: []Result{
+					{
+						RuleID:  "SQLI",
+						Level:   "warning",
+						Message: Description{Text: "Possible SQL injection."},
+						Locations: []Location{
+							{PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: "src/users/db.go"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	type hit struct {
+		Run, Result int
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		want       []hit
+		wantNilErr bool
+	}{
+		{
+			name:       "all terms required",
+			query:      "sql injection in payments",
+			want:       nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "any field",
+			query:      "SQL injection payments",
+			want:       []hit{{0, 0}},
+			wantNilErr: true,
+		},
+		{
+			name:       "across runs",
+			query:      "injection",
+			want:       []hit{{0, 0}, {1, 0}},
+			wantNilErr: true,
+		},
+		{
+			name:       "field filter",
+			query:      "message:injection",
+			want:       []hit{{1, 0}},
+			wantNilErr: true,
+		},
+		{
+			name:       "rule index",
+			query:      "rule:xss rule:scripting",
+			want:       []hit{{0, 1}},
+			wantNilErr: true,
+		},
+		{
+			name:       "level",
+			query:      "user level:warning",
+			want:       []hit{{0, 1}},
+			wantNilErr: true,
+		},
+		{
+			name:       "file",
+			query:      "file:db.go",
+			want:       []hit{{0, 0}, {1, 0}},
+			wantNilErr: true,
+		},
+		{
+			name:       "no match",
+			query:      "overflow",
+			want:       nil,
+			wantNilErr: true,
+		},
+		{
+			name:       "unknown field",
+			query:      "foo:bar",
+			wantNilErr: false,
+		},
+	}
+
+	idx := NewSearchIndex(l)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := idx.Search(tt.query)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			var got []hit
+			for _, h := range hits {
+				r := l.Runs[h.RunIndex].Results[h.ResultIndex]
+				if diff := cmp.Diff(r, h.Result); diff != "" {
+					t.Errorf("result mismatch (-want +got):\n%v", diff)
+				}
+				got = append(got, hit{h.RunIndex, h.ResultIndex})
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("hits mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
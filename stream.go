@@ -0,0 +1,217 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// FilterStream copies the SARIF document read from r to w, dropping
+// the results for which keep returns false. Only one result is held in
+// memory at a time, so it can be used to filter documents that do not
+// fit in memory. The [Result] passed to keep has [Result.Raw] set.
+// The kept results and the members of the log and its runs, other than
+// the runs and the results themselves, are copied byte for byte. The
+// object keys are copied without HTML escaping and the whitespace
+// between them is dropped. Null values are copied as they are.
+//
+// The output is written to w as the input is read, so, if an error is
+// returned, w may contain an incomplete document. Callers that need
+// the output to be written atomically should write it to a temporary
+// buffer or file first.
+func FilterStream(r io.Reader, w io.Writer, keep func(Result) bool) error {
+	bw := bufio.NewWriter(w)
+	f := &streamFilter{
+		dec:  json.NewDecoder(r),
+		w:    bw,
+		keep: keep,
+	}
+
+	if err := f.copyValue(streamLog); err != nil {
+		return fmt.Errorf("filter SARIF document: %w", err)
+	}
+	if !f.versionOK {
+		return errors.New("filter SARIF document: missing SARIF version")
+	}
+	bw.WriteString("\n")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write SARIF document: %w", err)
+	}
+	return nil
+}
+
+// streamKind identifies the kind of the JSON value being copied by a
+// [streamFilter].
+type streamKind int
+
+const (
+	streamOther streamKind = iota
+	streamLog
+	streamRuns
+	streamRun
+	streamResults
+)
+
+// streamFilter contains the state of [FilterStream].
+type streamFilter struct {
+	dec       *json.Decoder
+	w         *bufio.Writer
+	keep      func(Result) bool
+	versionOK bool
+}
+
+// copyValue copies the next JSON value, which is of the specified
+// kind. Values of kind streamOther are copied byte for byte.
+func (f *streamFilter) copyValue(kind streamKind) error {
+	if kind == streamOther {
+		var raw json.RawMessage
+		if err := f.dec.Decode(&raw); err != nil {
+			return err
+		}
+		f.w.Write(raw)
+		return nil
+	}
+
+	tok, err := f.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		return f.copyObject(kind)
+	case json.Delim('['):
+		return f.copyArray(kind)
+	}
+
+	if tok != nil {
+		return fmt.Errorf("unexpected JSON value: %v", tok)
+	}
+	return f.writeJSON(tok)
+}
+
+// copyObject copies the members of a JSON object of the specified
+// kind. The opening delimiter must have already been read.
+func (f *streamFilter) copyObject(kind streamKind) error {
+	if kind != streamLog && kind != streamRun {
+		return errors.New("unexpected JSON object")
+	}
+
+	f.w.WriteString("{")
+	for i := 0; f.dec.More(); i++ {
+		tok, err := f.dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("unexpected object key: %v", tok)
+		}
+
+		if i > 0 {
+			f.w.WriteString(",")
+		}
+		if err := f.writeJSON(key); err != nil {
+			return err
+		}
+		f.w.WriteString(":")
+
+		child := streamOther
+		switch {
+		case kind == streamLog && key == "version":
+			var version string
+			if err := f.dec.Decode(&version); err != nil {
+				return err
+			}
+			if version != sarifVersion {
+				return fmt.Errorf("unsupported SARIF version: %v", version)
+			}
+			f.versionOK = true
+			if err := f.writeJSON(version); err != nil {
+				return err
+			}
+			continue
+		case kind == streamLog && key == "runs":
+			child = streamRuns
+		case kind == streamRun && key == "results":
+			child = streamResults
+		}
+		if err := f.copyValue(child); err != nil {
+			return err
+		}
+	}
+	if _, err := f.dec.Token(); err != nil {
+		return err
+	}
+	f.w.WriteString("}")
+	return nil
+}
+
+// copyArray copies the elements of a JSON array of the specified
+// kind. The opening delimiter must have already been read. The
+// elements of a results array are only copied if they pass the
+// filter.
+func (f *streamFilter) copyArray(kind streamKind) error {
+	if kind != streamRuns && kind != streamResults {
+		return errors.New("unexpected JSON array")
+	}
+
+	f.w.WriteString("[")
+	n := 0
+	for f.dec.More() {
+		if kind == streamResults {
+			var raw json.RawMessage
+			if err := f.dec.Decode(&raw); err != nil {
+				return err
+			}
+			var result Result
+			if err := json.Unmarshal(raw, &result); err != nil {
+				return err
+			}
+			result.Raw = raw
+			if !f.keep(result) {
+				continue
+			}
+			if n > 0 {
+				f.w.WriteString(",")
+			}
+			f.w.Write(raw)
+			n++
+			continue
+		}
+
+		if n > 0 {
+			f.w.WriteString(",")
+		}
+		child := streamOther
+		if kind == streamRuns {
+			child = streamRun
+		}
+		if err := f.copyValue(child); err != nil {
+			return err
+		}
+		n++
+	}
+	if _, err := f.dec.Token(); err != nil {
+		return err
+	}
+	f.w.WriteString("]")
+	return nil
+}
+
+// writeJSON writes the JSON encoding of v without HTML escaping.
+func (f *streamFilter) writeJSON(v any) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	f.w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilterStream(t *testing.T) {
+	govulncheck, err := os.ReadFile("testdata/govulncheck.json")
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		data        string
+		keep        func(Result) bool
+		wantRuleIDs [][]string
+		wantNilErr  bool
+	}{
+		{
+			name:        "keep all",
+			data:        string(govulncheck),
+			keep:        func(Result) bool { return true },
+			wantRuleIDs: [][]string{{"GO-2021-0113", "GO-2022-1059"}},
+			wantNilErr:  true,
+		},
+		{
+			name:        "drop all",
+			data:        string(govulncheck),
+			keep:        func(Result) bool { return false },
+			wantRuleIDs: [][]string{nil},
+			wantNilErr:  true,
+		},
+		{
+			name: "multiple runs",
+			data: `{
+				"version": "2.1.0",
+				"runs": [
+					{"tool": {"driver": {"name": "a"}}, "results": [{"ruleId": "r1", "level": "error"}, {"ruleId": "r2"}]},
+					{"tool": {"driver": {"name": "b"}}, "results": [{"ruleId": "r3"}, {"ruleId": "r4", "level": "error"}]}
+				]
+			}`,
+			keep:        func(r Result) bool { return r.Level == "error" },
+			wantRuleIDs: [][]string{{"r1"}, {"r4"}},
+			wantNilErr:  true,
+		},
+		{
+			name: "raw result",
+			data: `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "a"}}, "results": [{"ruleId": "r1", "properties": {"x": 1}}, {"ruleId": "r2"}]}]}`,
+			keep: func(r Result) bool {
+				return strings.Contains(string(r.Raw), `"x"`)
+			},
+			wantRuleIDs: [][]string{{"r1"}},
+			wantNilErr:  true,
+		},
+		{
+			name:        "null runs",
+			data:        `{"version": "2.1.0", "runs": null}`,
+			keep:        func(Result) bool { return true },
+			wantRuleIDs: nil,
+			wantNilErr:  true,
+		},
+		{
+			name:        "null results",
+			data:        `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "a"}}, "results": null}]}`,
+			keep:        func(Result) bool { return true },
+			wantRuleIDs: [][]string{nil},
+			wantNilErr:  true,
+		},
+		{
+			name:       "invalid version",
+			data:       `{"version": "2.0.0", "runs": []}`,
+			keep:       func(Result) bool { return true },
+			wantNilErr: false,
+		},
+		{
+			name:       "missing version",
+			data:       `{"runs": []}`,
+			keep:       func(Result) bool { return true },
+			wantNilErr: false,
+		},
+		{
+			name:       "malformed",
+			data:       `{"version": "2.1.0", "runs": [`,
+			keep:       func(Result) bool { return true },
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := FilterStream(strings.NewReader(tt.data), &buf, tt.keep)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			l, err := Decode(&buf)
+			if err != nil {
+				t.Fatalf("decode filtered document: %v", err)
+			}
+
+			var ruleIDs [][]string
+			for _, run := range l.Runs {
+				var ids []string
+				for _, r := range run.Results {
+					ids = append(ids, r.RuleID)
+				}
+				ruleIDs = append(ruleIDs, ids)
+			}
+			if diff := cmp.Diff(tt.wantRuleIDs, ruleIDs); diff != "" {
+				t.Errorf("rule IDs mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestFilterStreamOriginalBytes(t *testing.T) {
+	data := `{
+		"version": "2.1.0",
+		"runs": [
+			{
+				"tool": {"driver": {"name": "a<b>&c", "version": 1.0}},
+				"results": [{"ruleId": "r1", "message": {"text": "x < y && z"}}, {"ruleId": "r2"}]
+			}
+		]
+	}`
+
+	var buf bytes.Buffer
+	if err := FilterStream(strings.NewReader(data), &buf, func(r Result) bool { return r.RuleID == "r1" }); err != nil {
+		t.Fatalf("filter stream: %v", err)
+	}
+
+	want := `{"version":"2.1.0","runs":[{"tool":{"driver": {"name": "a<b>&c", "version": 1.0}},"results":[{"ruleId": "r1", "message": {"text": "x < y && z"}}]}]}` + "\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("output mismatch (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func init() {
+	RegisterConverter("tap", tapConverter{})
+}
+
+// EncodeTAP writes the results of the [Log] to w using version 13 of
+// the [Test Anything Protocol]. Every location of every result
+// produces a failing test point named after the rule and the
+// location. Results without locations produce a single test point.
+// Declared rules without results produce a passing test point, so
+// the number of test points reflects the rules that were checked. The
+// level and the message of the result are written as a YAML
+// diagnostic block.
+//
+// [Test Anything Protocol]: https://testanything.org/tap-version-13-specification.html
+func (l Log) EncodeTAP(w io.Writer) error {
+	type testPoint struct {
+		ok          bool
+		description string
+		level       string
+		message     string
+	}
+
+	var points []testPoint
+	for _, run := range l.Runs {
+		used := make(map[string]bool)
+		for _, result := range run.Results {
+			rule := run.resultRuleID(result)
+			used[rule] = true
+
+			if rule == "" {
+				rule = run.Tool.Driver.Name
+			}
+
+			locs := []string{""}
+			if len(result.Locations) > 0 {
+				locs = locs[:0]
+				for _, loc := range result.Locations {
+					locs = append(locs, loc.PhysicalLocation.String())
+				}
+			}
+			for _, loc := range locs {
+				desc := rule
+				if loc != "" {
+					desc += " " + loc
+				}
+				points = append(points, testPoint{
+					description: desc,
+					level:       result.Level,
+					message:     result.Message.Text,
+				})
+			}
+		}
+		for _, rule := range run.Tool.Driver.Rules {
+			if !used[rule.ID] {
+				points = append(points, testPoint{ok: true, description: rule.ID})
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "TAP version 13")
+	fmt.Fprintf(bw, "1..%v\n", len(points))
+	for i, p := range points {
+		status := "not ok"
+		if p.ok {
+			status = "ok"
+		}
+		fmt.Fprintf(bw, "%v %v - %v\n", status, i+1, tapEscape(p.description))
+		if p.ok {
+			continue
+		}
+
+		fmt.Fprintln(bw, "  ---")
+		if p.level != "" {
+			fmt.Fprintf(bw, "  severity: %v\n", p.level)
+		}
+		if p.message != "" {
+			fmt.Fprintln(bw, "  message: |-")
+			for _, line := range strings.Split(p.message, "\n") {
+				fmt.Fprintf(bw, "    %v\n", line)
+			}
+		}
+		fmt.Fprintln(bw, "  ...")
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write TAP output: %w", err)
+	}
+	return nil
+}
+
+// tapEscape escapes the characters of a test point description that
+// have a special meaning in TAP.
+func tapEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "#", "\\#")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// tapConverter converts SARIF documents into TAP. It is registered
+// with the name "tap".
+type tapConverter struct{}
+
+// FromNative returns [ErrUnsupportedConversion].
+func (tapConverter) FromNative(r io.Reader) (Log, error) {
+	return Log{}, ErrUnsupportedConversion
+}
+
+// ToNative writes the results of the [Log] using [Log.EncodeTAP].
+func (tapConverter) ToNative(l Log, w io.Writer) error {
+	return l.EncodeTAP(w)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLog_EncodeTAP(t *testing.T) {
+	l := Log{
+		Version: "2.1.0",
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Name: "linter",
+						Rules: []Rule{
+							{ID: "R1"},
+							{ID: "R2"},
+						},
+					},
+				},
+				Results: []Result{
+					{
+						RuleIndex: ptr(0),
+						Level:     "error",
+						Message:   Description{Text: "First line.\nSecond line."},
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "a.go"},
+									Region:           Region{StartLine: 1, StartColumn: 2},
+								},
+							},
+							{
+								PhysicalLocation: PhysicalLocation{
+									ArtifactLocation: ArtifactLocation{URI: "b#c.go"},
+								},
+							},
+						},
+					},
+					{
+						Message: Description{Text: "Tool failure."},
+					},
+				},
+			},
+		},
+	}
+
+	want := `TAP version 13
+1..4
+not ok 1 - R1 a.go:1:2
+  ---
+  severity: error
+  message: |-
+    First line.
+    Second line.
+  ...
+not ok 2 - R1 b\#c.go
+  ---
+  severity: error
+  message: |-
+    First line.
+    Second line.
+  ...
+not ok 3 - linter
+  ---
+  message: |-
+    Tool failure.
+  ...
+ok 4 - R2
+`
+
+	var buf bytes.Buffer
+	if err := l.EncodeTAP(&buf); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("TAP output mismatch (-want +got):\n%v", diff)
+	}
+
+	c, found := LookupConverter("tap")
+	if !found {
+		t.Fatalf("converter not found")
+	}
+	buf.Reset()
+	if err := c.ToNative(l, &buf); err != nil {
+		t.Fatalf("conversion error: %v", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("converter output mismatch (-want +got):\n%v", diff)
+	}
+}
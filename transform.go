@@ -0,0 +1,628 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+// LimitResultsPerRule returns a copy of the provided [Log] where every
+// run contains at most n results per rule, as resolved by
+// [Run.ResultRule]. The results exceeding the limit are replaced with
+// a single synthetic result, placed where the first removed result
+// was, whose message reports the number of removed results and whose
+// [Result.OccurrenceCount] is set to that number. The synthetic result
+// has the rule reference, the level and the first location of the
+// first removed result, so consumers that require results to have a
+// location accept it. Results without rule are not limited. If n is
+// zero or negative, the log is returned unchanged.
+func (l Log) LimitResultsPerRule(n int) Log {
+	if n <= 0 {
+		return l
+	}
+
+	runs := make([]Run, len(l.Runs))
+	for i, run := range l.Runs {
+		ids := make([]string, len(run.Results))
+		counts := make(map[string]int)
+		for j, result := range run.Results {
+			ids[j] = run.resultRuleID(result)
+			counts[ids[j]]++
+		}
+
+		var results []Result
+		seen := make(map[string]int)
+		for j, result := range run.Results {
+			id := ids[j]
+			if id == "" {
+				results = append(results, result)
+				continue
+			}
+			seen[id]++
+			switch k := seen[id]; {
+			case k <= n:
+				results = append(results, result)
+			case k == n+1:
+				more := counts[id] - n
+				results = append(results, Result{
+					RuleID:    result.RuleID,
+					RuleIndex: result.RuleIndex,
+					Rule:      result.Rule,
+					Level:     result.Level,
+					Message: Description{
+						Text: fmt.Sprintf("and %v more occurrences of %v.", formatCount(more), id),
+					},
+					Locations:       slices.Clone(result.Locations[:min(len(result.Locations), 1)]),
+					OccurrenceCount: more,
+				})
+			}
+		}
+		if results == nil && run.Results != nil {
+			results = []Result{}
+		}
+		run.Results = results
+		runs[i] = run
+	}
+	l.Runs = runs
+	return l
+}
+
+// formatCount formats n using commas as thousands separators.
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return sign + s
+}
+
+// Sample returns a copy of the provided [Log] where every run only
+// contains a representative subset of its results. Results are
+// grouped by level and by rule, as resolved by [Run.ResultRule], and
+// rate is the fraction of results kept from every group, with at least
+// one result kept per group. The selection is deterministic for a
+// given seed and the sampled results keep their original order. The
+// sampling rate is recorded in the "samplingRate" property of every
+// run. If rate is greater than or equal to 1, the log is returned
+// unchanged.
+func (l Log) Sample(rate float64, seed int64) Log {
+	if rate >= 1 {
+		return l
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	runs := make([]Run, len(l.Runs))
+	for i, run := range l.Runs {
+		type stratum struct{ ruleID, level string }
+
+		var strata []stratum
+		groups := make(map[stratum][]int)
+		for j, result := range run.Results {
+			s := stratum{run.resultRuleID(result), result.Level}
+			if _, ok := groups[s]; !ok {
+				strata = append(strata, s)
+			}
+			groups[s] = append(groups[s], j)
+		}
+
+		var keep []int
+		for _, s := range strata {
+			idxs := groups[s]
+			n := max(int(math.Ceil(rate*float64(len(idxs)))), 1)
+			for _, k := range rnd.Perm(len(idxs))[:n] {
+				keep = append(keep, idxs[k])
+			}
+		}
+		slices.Sort(keep)
+
+		if run.Results != nil {
+			results := make([]Result, 0, len(keep))
+			for _, j := range keep {
+				results = append(results, run.Results[j])
+			}
+			run.Results = results
+		}
+
+		run.Properties = maps.Clone(run.Properties)
+		if run.Properties == nil {
+			run.Properties = make(map[string]any)
+		}
+		run.Properties["samplingRate"] = rate
+
+		runs[i] = run
+	}
+	l.Runs = runs
+	return l
+}
+
+// TruncateContents returns a copy of the provided [Log] where the
+// contents of the artifacts of every run and the snippets of the
+// regions and context regions of the locations and related locations
+// of every result are at most n bytes long. Textual contents are
+// truncated at a character boundary and binary contents are truncated
+// at a Base64 quantum boundary. Truncated contents have the
+// "truncated" property set to true. It is meant to be applied after
+// embedding contents, for instance with [PopulateContextRegions], so
+// a single large artifact does not make the log unusable. If n is
+// zero or negative, the log is returned unchanged.
+func (l Log) TruncateContents(n int) Log {
+	if n <= 0 {
+		return l
+	}
+
+	truncateLocations := func(locs []Location) []Location {
+		locs = slices.Clone(locs)
+		for i := range locs {
+			ploc := &locs[i].PhysicalLocation
+			ploc.Region.Snippet = truncateContent(ploc.Region.Snippet, n)
+			if cr := ploc.ContextRegion; cr != nil {
+				truncated := *cr
+				truncated.Snippet = truncateContent(cr.Snippet, n)
+				ploc.ContextRegion = &truncated
+			}
+		}
+		return locs
+	}
+
+	runs := make([]Run, len(l.Runs))
+	for i, run := range l.Runs {
+		run.Artifacts = slices.Clone(run.Artifacts)
+		for j := range run.Artifacts {
+			run.Artifacts[j].Contents = truncateContent(run.Artifacts[j].Contents, n)
+		}
+
+		run.Results = slices.Clone(run.Results)
+		for j := range run.Results {
+			result := &run.Results[j]
+			result.Locations = truncateLocations(result.Locations)
+			result.RelatedLocations = truncateLocations(result.RelatedLocations)
+		}
+		runs[i] = run
+	}
+	l.Runs = runs
+	return l
+}
+
+// truncateContent returns a copy of c whose text and binary contents
+// are at most n bytes long. See [Log.TruncateContents]. If c is nil or
+// does not need to be truncated, c is returned.
+func truncateContent(orig *ArtifactContent, n int) *ArtifactContent {
+	if orig == nil {
+		return nil
+	}
+
+	c := *orig
+	truncated := false
+	if len(c.Text) > n {
+		end := n
+		for end > 0 && !utf8.RuneStart(c.Text[end]) {
+			end--
+		}
+		c.Text = c.Text[:end]
+		truncated = true
+	}
+	if len(c.Binary) > n {
+		c.Binary = c.Binary[:n/4*4]
+		truncated = true
+	}
+
+	if truncated {
+		c.Properties = maps.Clone(c.Properties)
+		if c.Properties == nil {
+			c.Properties = make(map[string]any)
+		}
+		c.Properties["truncated"] = true
+		return &c
+	}
+	return orig
+}
+
+// LimitCodeFlows returns a copy of the provided [Log] where every
+// result has at most maxFlows code flows and every thread flow has at
+// most maxSteps locations. The shortest code flows, measured in
+// thread flow locations, are kept in their original order. The first
+// and the last location of a thread flow are always kept, and the
+// remaining steps are chosen by [ThreadFlowLocation.Importance] and
+// then by order of appearance. The number of removed code flows is
+// recorded in the "elidedCodeFlows" property of the result and the
+// number of removed locations in the "elidedLocations" property of the
+// thread flow. If maxFlows or maxSteps is zero or negative, the
+// corresponding limit is not applied.
+func (l Log) LimitCodeFlows(maxFlows, maxSteps int) Log {
+	runs := make([]Run, len(l.Runs))
+	for i, run := range l.Runs {
+		run.Results = slices.Clone(run.Results)
+		for j := range run.Results {
+			result := &run.Results[j]
+
+			flows := result.CodeFlows
+			if maxFlows > 0 && len(flows) > maxFlows {
+				idxs := make([]int, len(flows))
+				for k := range idxs {
+					idxs[k] = k
+				}
+				slices.SortStableFunc(idxs, func(a, b int) int {
+					return cmp.Compare(codeFlowLen(flows[a]), codeFlowLen(flows[b]))
+				})
+				idxs = idxs[:maxFlows]
+				slices.Sort(idxs)
+
+				var kept []CodeFlow
+				for _, k := range idxs {
+					kept = append(kept, flows[k])
+				}
+				result.Properties = maps.Clone(result.Properties)
+				if result.Properties == nil {
+					result.Properties = make(map[string]any)
+				}
+				result.Properties["elidedCodeFlows"] = len(flows) - maxFlows
+				flows = kept
+			}
+
+			if maxSteps > 0 {
+				flows = slices.Clone(flows)
+				for k := range flows {
+					flows[k].ThreadFlows = slices.Clone(flows[k].ThreadFlows)
+					for m := range flows[k].ThreadFlows {
+						flows[k].ThreadFlows[m] = limitThreadFlow(flows[k].ThreadFlows[m], maxSteps)
+					}
+				}
+			}
+			result.CodeFlows = flows
+		}
+		runs[i] = run
+	}
+	l.Runs = runs
+	return l
+}
+
+// codeFlowLen returns the number of thread flow locations of the code
+// flow.
+func codeFlowLen(flow CodeFlow) int {
+	n := 0
+	for _, tf := range flow.ThreadFlows {
+		n += len(tf.Locations)
+	}
+	return n
+}
+
+// limitThreadFlow returns a copy of the thread flow with at most n
+// locations. See [Log.LimitCodeFlows].
+func limitThreadFlow(tf ThreadFlow, n int) ThreadFlow {
+	locs := tf.Locations
+	if len(locs) <= n {
+		return tf
+	}
+
+	importance := func(i int) int {
+		switch locs[i].Importance {
+		case "essential":
+			return 0
+		case "unimportant":
+			return 2
+		}
+		return 1
+	}
+
+	idxs := make([]int, len(locs))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	slices.SortStableFunc(idxs, func(a, b int) int {
+		// The first and the last locations go first, so they
+		// are always kept.
+		ea := a == 0 || a == len(locs)-1
+		eb := b == 0 || b == len(locs)-1
+		switch {
+		case ea && !eb:
+			return -1
+		case !ea && eb:
+			return 1
+		}
+		return cmp.Compare(importance(a), importance(b))
+	})
+	idxs = idxs[:n]
+	slices.Sort(idxs)
+
+	var kept []ThreadFlowLocation
+	for _, i := range idxs {
+		kept = append(kept, locs[i])
+	}
+	tf.Locations = kept
+
+	tf.Properties = maps.Clone(tf.Properties)
+	if tf.Properties == nil {
+		tf.Properties = make(map[string]any)
+	}
+	tf.Properties["elidedLocations"] = len(locs) - n
+	return tf
+}
+
+// MergeAdjacentResults returns a copy of the provided [Log] where the
+// results of every run that have the same rule, as resolved by
+// [Run.ResultRule], and the same level, and whose only location refers
+// to overlapping or adjacent regions of the same artifact, are merged
+// into a single result. Two regions are adjacent if one ends where the
+// other starts, or if one ends at the end of a line and the other
+// starts at the beginning of the next line. The merged result is
+// placed where the first merged result was, its region spans the
+// merged regions and its [Result.OccurrenceCount] is the sum of the
+// occurrences of the merged results, counting results without
+// occurrence count as one. The snippet and the context region of the
+// merged result are removed, since they no longer match the region.
+// Results with several locations or without region are left untouched.
+func (l Log) MergeAdjacentResults() Log {
+	type mergeKey struct {
+		ruleID, level, uriBaseID, uri string
+	}
+
+	runs := make([]Run, len(l.Runs))
+	for i, run := range l.Runs {
+		var keys []mergeKey
+		groups := make(map[mergeKey][]int)
+		for j, result := range run.Results {
+			if len(result.Locations) != 1 || result.Locations[0].PhysicalLocation.Region.StartLine == 0 {
+				continue
+			}
+			aloc := result.Locations[0].PhysicalLocation.ArtifactLocation
+			k := mergeKey{run.resultRuleID(result), result.Level, aloc.URIBaseID, aloc.URI}
+			if _, ok := groups[k]; !ok {
+				keys = append(keys, k)
+			}
+			groups[k] = append(groups[k], j)
+		}
+
+		region := func(j int) Region {
+			return run.Results[j].Locations[0].PhysicalLocation.Region
+		}
+
+		merged := make(map[int]Result)
+		removed := make(map[int]bool)
+		for _, k := range keys {
+			idxs := groups[k]
+			slices.SortStableFunc(idxs, func(a, b int) int {
+				return compareRegionStarts(region(a), region(b))
+			})
+
+			for start := 0; start < len(idxs); {
+				end := start + 1
+				union := region(idxs[start])
+				for end < len(idxs) && regionsTouch(union, region(idxs[end])) {
+					union = regionUnion(union, region(idxs[end]))
+					end++
+				}
+
+				if end-start > 1 {
+					cluster := idxs[start:end]
+					first := slices.Min(cluster)
+
+					result := run.Results[idxs[start]]
+					result.OccurrenceCount = 0
+					for _, j := range cluster {
+						result.OccurrenceCount += max(run.Results[j].OccurrenceCount, 1)
+						removed[j] = true
+					}
+					result.Locations = slices.Clone(result.Locations)
+					ploc := &result.Locations[0].PhysicalLocation
+					ploc.Region = union
+					ploc.Region.Snippet = nil
+					ploc.ContextRegion = nil
+					merged[first] = result
+				}
+				start = end
+			}
+		}
+
+		if len(merged) > 0 {
+			var results []Result
+			for j, result := range run.Results {
+				if m, ok := merged[j]; ok {
+					results = append(results, m)
+				} else if !removed[j] {
+					results = append(results, result)
+				}
+			}
+			run.Results = results
+		}
+		runs[i] = run
+	}
+	l.Runs = runs
+	return l
+}
+
+// regionStart returns the start line and column of the region.
+func regionStart(r Region) (line, col int) {
+	return r.StartLine, max(r.StartColumn, 1)
+}
+
+// regionEnd returns the end line and column of the region. The column
+// is [math.MaxInt] if the region ends at the end of the line.
+func regionEnd(r Region) (line, col int) {
+	line = max(r.EndLine, r.StartLine)
+	if r.EndColumn == 0 {
+		return line, math.MaxInt
+	}
+	return line, r.EndColumn
+}
+
+// compareRegionStarts compares the start positions of two regions.
+func compareRegionStarts(a, b Region) int {
+	aline, acol := regionStart(a)
+	bline, bcol := regionStart(b)
+	if c := cmp.Compare(aline, bline); c != 0 {
+		return c
+	}
+	return cmp.Compare(acol, bcol)
+}
+
+// regionsTouch reports whether region b, which does not start before
+// region a, overlaps or is adjacent to a.
+func regionsTouch(a, b Region) bool {
+	aline, acol := regionEnd(a)
+	bline, bcol := regionStart(b)
+	if bline < aline || (bline == aline && bcol <= acol) {
+		return true
+	}
+	return acol == math.MaxInt && bline == aline+1 && bcol == 1
+}
+
+// regionUnion returns the smallest region that contains the regions a
+// and b, where b does not start before a.
+func regionUnion(a, b Region) Region {
+	aline, acol := regionEnd(a)
+	bline, bcol := regionEnd(b)
+	if bline > aline || (bline == aline && bcol > acol) {
+		if bline != a.StartLine {
+			a.EndLine = bline
+		}
+		a.EndColumn = b.EndColumn
+	}
+	return a
+}
+
+// MergeDuplicateRules returns a copy of the provided [Log] where the
+// driver of every run declares each rule identifier only once. Rules
+// with the same identifier are merged into the first of them: the
+// fields it leaves empty are taken from the following ones, in order,
+// and their properties, message strings and relationships are added
+// to it. The rule indices of the results that refer to the rules of
+// the driver are rewritten to match the merged rules. Rules without
+// identifier are kept as they are.
+func (l Log) MergeDuplicateRules() Log {
+	runs := make([]Run, len(l.Runs))
+	for i, run := range l.Runs {
+		driver := run.Tool.Driver
+
+		var rules []Rule
+		first := make(map[string]int)
+		indices := make([]int, len(driver.Rules))
+		for j, rule := range driver.Rules {
+			if k, ok := first[rule.ID]; ok && rule.ID != "" {
+				rules[k] = mergeRule(rules[k], rule)
+				indices[j] = k
+				continue
+			}
+			first[rule.ID] = len(rules)
+			indices[j] = len(rules)
+			rules = append(rules, rule)
+		}
+		if len(rules) == len(driver.Rules) {
+			runs[i] = run
+			continue
+		}
+		driver.Rules = rules
+		run.Tool.Driver = driver
+
+		remap := func(idx *int) *int {
+			if idx == nil || *idx < 0 || *idx >= len(indices) {
+				return idx
+			}
+			k := indices[*idx]
+			return &k
+		}
+		results := slices.Clone(run.Results)
+		for j, result := range results {
+			if result.Rule != nil {
+				if !result.Rule.ToolComponent.refersTo(driver) {
+					continue
+				}
+				ref := *result.Rule
+				ref.Index = remap(ref.Index)
+				result.Rule = &ref
+			}
+			result.RuleIndex = remap(result.RuleIndex)
+			results[j] = result
+		}
+		run.Results = results
+		runs[i] = run
+	}
+	l.Runs = runs
+	return l
+}
+
+// mergeRule returns a copy of dst with the empty fields set to the
+// corresponding fields of src and with the deprecated identifiers,
+// properties, message strings and relationships of src added to it.
+func mergeRule(dst, src Rule) Rule {
+	if dst.GUID == "" {
+		dst.GUID = src.GUID
+	}
+	if dst.Name == "" {
+		dst.Name = src.Name
+	}
+	dst.DeprecatedIDs = appendMissing(dst.DeprecatedIDs, src.DeprecatedIDs...)
+	dst.DeprecatedGUIDs = appendMissing(dst.DeprecatedGUIDs, src.DeprecatedGUIDs...)
+	dst.DeprecatedNames = appendMissing(dst.DeprecatedNames, src.DeprecatedNames...)
+	if dst.ShortDescription.isEmpty() {
+		dst.ShortDescription = src.ShortDescription
+	}
+	if dst.FullDescription.isEmpty() {
+		dst.FullDescription = src.FullDescription
+	}
+	if dst.Help.isEmpty() {
+		dst.Help = src.Help
+	}
+	if dst.HelpURI == "" {
+		dst.HelpURI = src.HelpURI
+	}
+	if dst.DefaultConfiguration == nil {
+		dst.DefaultConfiguration = src.DefaultConfiguration
+	}
+	dst.Properties = mergeMissing(dst.Properties, src.Properties)
+	dst.MessageStrings = mergeMissing(dst.MessageStrings, src.MessageStrings)
+
+	rels := slices.Clip(dst.Relationships)
+	for _, rel := range src.Relationships {
+		if !slices.ContainsFunc(rels, func(r Relationship) bool { return reflect.DeepEqual(r, rel) }) {
+			rels = append(rels, rel)
+		}
+	}
+	dst.Relationships = rels
+	return dst
+}
+
+// appendMissing returns a copy of s with the elements of elems that
+// are not in s appended to it.
+func appendMissing(s []string, elems ...string) []string {
+	s = slices.Clip(s)
+	for _, e := range elems {
+		if !slices.Contains(s, e) {
+			s = append(s, e)
+		}
+	}
+	return s
+}
+
+// mergeMissing returns a copy of dst with the entries of src whose
+// keys are not in dst. If src has no such entries, dst is returned.
+func mergeMissing[M ~map[K]V, K comparable, V any](dst, src M) M {
+	var merged M
+	for k, v := range src {
+		if _, ok := dst[k]; ok {
+			continue
+		}
+		if merged == nil {
+			merged = maps.Clone(dst)
+			if merged == nil {
+				merged = make(M)
+			}
+		}
+		merged[k] = v
+	}
+	if merged == nil {
+		return dst
+	}
+	return merged
+}
@@ -0,0 +1,917 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLog_LimitResultsPerRule(t *testing.T) {
+	l := Log{
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Rules: []Rule{{ID: "R1"}, {ID: "R2"}},
+					},
+				},
+				Results: []Result{
+					{RuleID: "R1", Level: "error", Message: Description{Text: "1"}},
+					{RuleID: "R2", Message: Description{Text: "2"}},
+					{RuleID: "R1", Level: "error", Message: Description{Text: "3"}},
+					{RuleIndex: ptr(0), Level: "warning", Message: Description{Text: "4"}, Locations: []Location{Location{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 4},
+						},
+					}, Location{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "b.go"},
+							Region:           Region{StartLine: 1},
+						},
+					}}},
+					{RuleID: "R1", Level: "error", Message: Description{Text: "5"}},
+					{Level: "error", Message: Description{Text: "6"}},
+					{Level: "error", Message: Description{Text: "7"}},
+					{Level: "error", Message: Description{Text: "8"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		n    int
+		want []Result
+	}{
+		{
+			name: "limited",
+			n:    2,
+			want: []Result{
+				{RuleID: "R1", Level: "error", Message: Description{Text: "1"}},
+				{RuleID: "R2", Message: Description{Text: "2"}},
+				{RuleID: "R1", Level: "error", Message: Description{Text: "3"}},
+				{
+					RuleIndex: ptr(0),
+					Level:     "warning",
+					Message:   Description{Text: "and 2 more occurrences of R1."},
+					Locations: []Location{Location{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: "a.go"},
+							Region:           Region{StartLine: 4},
+						},
+					}},
+					OccurrenceCount: 2,
+				},
+				{Level: "error", Message: Description{Text: "6"}},
+				{Level: "error", Message: Description{Text: "7"}},
+				{Level: "error", Message: Description{Text: "8"}},
+			},
+		},
+		{
+			name: "under limit",
+			n:    4,
+			want: l.Runs[0].Results,
+		},
+		{
+			name: "no limit",
+			n:    0,
+			want: l.Runs[0].Results,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := l.LimitResultsPerRule(tt.n)
+			if diff := cmp.Diff(tt.want, got.Runs[0].Results); diff != "" {
+				t.Errorf("results mismatch (-want +got):\n%v", diff)
+			}
+			if len(l.Runs[0].Results) != 8 {
+				t.Errorf("original log was modified")
+			}
+		})
+	}
+
+	clean := NewCleanLog(Tool{Driver: Driver{Name: "linter"}})
+	if !clean.LimitResultsPerRule(1).IsClean() {
+		t.Errorf("clean log is not clean after limiting results")
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{3482, "3,482"},
+		{1234567, "1,234,567"},
+		{-1000, "-1,000"},
+	}
+
+	for _, tt := range tests {
+		if got := formatCount(tt.n); got != tt.want {
+			t.Errorf("count mismatch: want: %v, got: %v", tt.want, got)
+		}
+	}
+}
+
+func TestLog_Sample(t *testing.T) {
+	var results []Result
+	for i := 0; i < 10; i++ {
+		results = append(results, Result{RuleID: "R1", Level: "error", Message: Description{Text: fmt.Sprint(i)}})
+	}
+	results[0].RuleID, results[0].RuleIndex = "", ptr(0)
+	for i := 0; i < 4; i++ {
+		results = append(results, Result{RuleID: "R1", Level: "warning"})
+	}
+	results = append(results, Result{RuleID: "R2", Level: "error"})
+
+	l := Log{
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Rules: []Rule{{ID: "R1"}},
+					},
+				},
+				Results:    results,
+				Properties: map[string]any{"foo": "bar"},
+			},
+		},
+	}
+
+	got := l.Sample(0.5, 1)
+
+	count := make(map[string]int)
+	for _, result := range got.Runs[0].Results {
+		count[got.Runs[0].resultRuleID(result)+"/"+result.Level]++
+	}
+	want := map[string]int{"R1/error": 5, "R1/warning": 2, "R2/error": 1}
+	if diff := cmp.Diff(want, count); diff != "" {
+		t.Errorf("strata mismatch (-want +got):\n%v", diff)
+	}
+
+	if !slices.IsSortedFunc(got.Runs[0].Results[:5], func(a, b Result) int {
+		return strings.Compare(a.Message.Text, b.Message.Text)
+	}) {
+		t.Errorf("results are not in original order")
+	}
+
+	wantProps := map[string]any{"foo": "bar", "samplingRate": 0.5}
+	if diff := cmp.Diff(wantProps, got.Runs[0].Properties); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%v", diff)
+	}
+	if _, ok := l.Runs[0].Properties["samplingRate"]; ok {
+		t.Errorf("original log was modified")
+	}
+
+	if diff := cmp.Diff(got, l.Sample(0.5, 1)); diff != "" {
+		t.Errorf("sampling is not deterministic (-want +got):\n%v", diff)
+	}
+
+	if diff := cmp.Diff(l, l.Sample(1, 1)); diff != "" {
+		t.Errorf("unexpected sampling (-want +got):\n%v", diff)
+	}
+
+	unknown := Log{Runs: []Run{{Tool: Tool{Driver: Driver{Name: "linter"}}}}}
+	if got := unknown.Sample(0.5, 1); got.Runs[0].Results != nil {
+		t.Errorf("unknown results became known: %#v", got.Runs[0].Results)
+	}
+}
+
+func TestLog_TruncateContents(t *testing.T) {
+	truncated := map[string]any{"truncated": true}
+
+	l := Log{
+		Runs: []Run{
+			{
+				Artifacts: []Artifact{
+					{Contents: &ArtifactContent{Text: "0123456789", Binary: "QUJDREVGR0g="}},
+				},
+				Results: []Result{
+					{
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									Region:        Region{StartLine: 1, Snippet: &ArtifactContent{Text: "short"}},
+									ContextRegion: &Region{StartLine: 1, Snippet: &ArtifactContent{Text: "héllo world"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	want := Log{
+		Runs: []Run{
+			{
+				Artifacts: []Artifact{
+					{Contents: &ArtifactContent{Text: "012345", Binary: "QUJD", Properties: truncated}},
+				},
+				Results: []Result{
+					{
+						Locations: []Location{
+							{
+								PhysicalLocation: PhysicalLocation{
+									Region:        Region{StartLine: 1, Snippet: &ArtifactContent{Text: "short"}},
+									ContextRegion: &Region{StartLine: 1, Snippet: &ArtifactContent{Text: "héllo", Properties: truncated}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := l.TruncateContents(6)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("log mismatch (-want +got):\n%v", diff)
+	}
+
+	if l.Runs[0].Artifacts[0].Contents.Text != "0123456789" || l.Runs[0].Results[0].Locations[0].PhysicalLocation.ContextRegion.Snippet.Text != "héllo world" {
+		t.Errorf("input log was modified")
+	}
+
+	if diff := cmp.Diff(l, l.TruncateContents(0)); diff != "" {
+		t.Errorf("log mismatch with zero limit (-want +got):\n%v", diff)
+	}
+
+	got = l.TruncateContents(2)
+	if text := got.Runs[0].Results[0].Locations[0].PhysicalLocation.ContextRegion.Snippet.Text; text != "h" {
+		t.Errorf("text not truncated at character boundary: %q", text)
+	}
+}
+
+func TestLog_LimitCodeFlows(t *testing.T) {
+	l := Log{
+		Runs: []Run{
+			{
+				Results: []Result{
+					{
+						RuleID: "R1",
+						CodeFlows: []CodeFlow{
+							{
+								Message: Description{Text: "long"},
+								ThreadFlows: []ThreadFlow{
+									{
+										Locations: []ThreadFlowLocation{
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 1}}}},
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 2}}}, Importance: "unimportant"},
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 3}}}, Importance: "essential"},
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 4}}}},
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 5}}}},
+										},
+									},
+								},
+							},
+							{
+								Message: Description{Text: "short"},
+								ThreadFlows: []ThreadFlow{
+									{
+										Locations: []ThreadFlowLocation{
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 1}}}},
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 2}}}},
+										},
+									},
+								},
+							},
+							{
+								Message: Description{Text: "medium"},
+								ThreadFlows: []ThreadFlow{
+									{
+										Locations: []ThreadFlowLocation{
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 1}}}},
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 2}}}},
+											{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 3}}}},
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						RuleID: "R2",
+						CodeFlows: []CodeFlow{{
+							Message: Description{Text: "only"},
+							ThreadFlows: []ThreadFlow{
+								{
+									Locations: []ThreadFlowLocation{
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 1}}}},
+									},
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		maxFlows int
+		maxSteps int
+		want     []Result
+	}{
+		{
+			name:     "flows",
+			maxFlows: 2,
+			want: []Result{
+				{
+					RuleID: "R1",
+					CodeFlows: []CodeFlow{
+						{
+							Message: Description{Text: "short"},
+							ThreadFlows: []ThreadFlow{
+								{
+									Locations: []ThreadFlowLocation{
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 1}}}},
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 2}}}},
+									},
+								},
+							},
+						},
+						{
+							Message: Description{Text: "medium"},
+							ThreadFlows: []ThreadFlow{
+								{
+									Locations: []ThreadFlowLocation{
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 1}}}},
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 2}}}},
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 3}}}},
+									},
+								},
+							},
+						},
+					},
+					Properties: map[string]any{"elidedCodeFlows": 1},
+				},
+				l.Runs[0].Results[1],
+			},
+		},
+		{
+			name:     "steps",
+			maxSteps: 3,
+			want: []Result{
+				{
+					RuleID: "R1",
+					CodeFlows: []CodeFlow{
+						{
+							Message: Description{Text: "long"},
+							ThreadFlows: []ThreadFlow{
+								{
+									Locations: []ThreadFlowLocation{
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 1}}}},
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 3}}}, Importance: "essential"},
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 5}}}},
+									},
+									Properties: map[string]any{"elidedLocations": 2},
+								},
+							},
+						},
+						{
+							Message: Description{Text: "short"},
+							ThreadFlows: []ThreadFlow{
+								{
+									Locations: []ThreadFlowLocation{
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 1}}}},
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 2}}}},
+									},
+								},
+							},
+						},
+						{
+							Message: Description{Text: "medium"},
+							ThreadFlows: []ThreadFlow{
+								{
+									Locations: []ThreadFlowLocation{
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 1}}}},
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 2}}}},
+										{Location: Location{PhysicalLocation: PhysicalLocation{Region: Region{StartLine: 3}}}},
+									},
+								},
+							},
+						},
+					},
+				},
+				l.Runs[0].Results[1],
+			},
+		},
+		{
+			name: "no limits",
+			want: l.Runs[0].Results,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := l.LimitCodeFlows(tt.maxFlows, tt.maxSteps)
+			if diff := cmp.Diff(tt.want, got.Runs[0].Results); diff != "" {
+				t.Errorf("results mismatch (-want +got):\n%v", diff)
+			}
+			if len(l.Runs[0].Results[0].CodeFlows) != 3 || len(l.Runs[0].Results[0].CodeFlows[0].ThreadFlows[0].Locations) != 5 {
+				t.Errorf("input log was modified")
+			}
+		})
+	}
+}
+
+func TestLog_MergeAdjacentResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []Result
+		want    []Result
+	}{
+		{
+			name: "same line",
+			results: []Result{
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, StartColumn: 5, EndColumn: 8},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R2",
+					Level:   "warning",
+					Message: Description{Text: "R2 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, StartColumn: 1},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, StartColumn: 1, EndColumn: 5, Snippet: &ArtifactContent{Text: "func"}},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, StartColumn: 6, EndColumn: 12},
+							},
+						},
+					},
+					OccurrenceCount: 2,
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, StartColumn: 13, EndColumn: 14},
+							},
+						},
+					},
+				},
+			},
+			want: []Result{
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, StartColumn: 1, EndColumn: 12},
+							},
+						},
+					},
+					OccurrenceCount: 4,
+				},
+				{
+					RuleID:  "R2",
+					Level:   "warning",
+					Message: Description{Text: "R2 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, StartColumn: 1},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, StartColumn: 13, EndColumn: 14},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "consecutive lines",
+			results: []Result{
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 3},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, EndLine: 2},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "b.go"},
+								Region:           Region{StartLine: 2},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 5},
+							},
+						},
+					},
+				},
+			},
+			want: []Result{
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, EndLine: 3},
+							},
+						},
+					},
+					OccurrenceCount: 2,
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "b.go"},
+								Region:           Region{StartLine: 2},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 5},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "rule index",
+			results: []Result{
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1},
+							},
+						},
+					},
+				},
+				{
+					RuleIndex: ptr(0),
+					Level:     "warning",
+					Message:   Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 2},
+							},
+						},
+					},
+				},
+				{
+					RuleIndex: ptr(1),
+					Level:     "warning",
+					Message:   Description{Text: "R2 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 3},
+							},
+						},
+					},
+				},
+			},
+			want: []Result{
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, EndLine: 2},
+							},
+						},
+					},
+					OccurrenceCount: 2,
+				},
+				{
+					RuleIndex: ptr(1),
+					Level:     "warning",
+					Message:   Description{Text: "R2 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 3},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "untouched",
+			results: []Result{
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, EndColumn: 3},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 2},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{},
+							},
+						},
+					},
+				},
+				{RuleID: "R1"},
+			},
+			want: []Result{
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 1, EndColumn: 3},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{StartLine: 2},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "R1",
+					Level:   "warning",
+					Message: Description{Text: "R1 fired."},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "a.go"},
+								Region:           Region{},
+							},
+						},
+					},
+				},
+				{RuleID: "R1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Log{
+				Runs: []Run{
+					{
+						Tool: Tool{
+							Driver: Driver{
+								Rules: []Rule{{ID: "R1"}, {ID: "R2"}},
+							},
+						},
+						Results: tt.results,
+					},
+				},
+			}
+			got := l.MergeAdjacentResults()
+			if diff := cmp.Diff(tt.want, got.Runs[0].Results); diff != "" {
+				t.Errorf("results mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestLog_MergeDuplicateRules(t *testing.T) {
+	l := Log{
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Name: "linter",
+						Rules: []Rule{
+							{
+								ID:               "R1",
+								ShortDescription: Description{Text: "Rule 1."},
+								Properties:       map[string]any{"tags": []any{"security"}},
+							},
+							{ID: "R2"},
+							{
+								ID:               "R1",
+								ShortDescription: Description{Text: "Duplicate."},
+								HelpURI:          "https://example.com/R1",
+								Properties:       map[string]any{"tags": []any{"style"}, "precision": "high"},
+								MessageStrings:   map[string]Description{"default": {Text: "Found {0}."}},
+							},
+							{ID: "R3"},
+						},
+					},
+				},
+				Results: []Result{
+					{RuleID: "R3", RuleIndex: ptr(3)},
+					{RuleID: "R1", RuleIndex: ptr(2)},
+					{Rule: &ReportingDescriptorReference{ID: "R1", Index: ptr(2)}},
+					{
+						RuleID:    "R1",
+						RuleIndex: ptr(2),
+						Rule: &ReportingDescriptorReference{
+							ToolComponent: &ToolComponentReference{Name: "plugin"},
+						},
+					},
+					{RuleID: "R2"},
+				},
+			},
+		},
+	}
+
+	want := Run{
+		Tool: Tool{
+			Driver: Driver{
+				Name: "linter",
+				Rules: []Rule{
+					{
+						ID:               "R1",
+						ShortDescription: Description{Text: "Rule 1."},
+						HelpURI:          "https://example.com/R1",
+						Properties:       map[string]any{"tags": []any{"security"}, "precision": "high"},
+						MessageStrings:   map[string]Description{"default": {Text: "Found {0}."}},
+					},
+					{ID: "R2"},
+					{ID: "R3"},
+				},
+			},
+		},
+		Results: []Result{
+			{RuleID: "R3", RuleIndex: ptr(2)},
+			{RuleID: "R1", RuleIndex: ptr(0)},
+			{Rule: &ReportingDescriptorReference{ID: "R1", Index: ptr(0)}},
+			{
+				RuleID:    "R1",
+				RuleIndex: ptr(2),
+				Rule: &ReportingDescriptorReference{
+					ToolComponent: &ToolComponentReference{Name: "plugin"},
+				},
+			},
+			{RuleID: "R2"},
+		},
+	}
+
+	got := l.MergeDuplicateRules()
+	if diff := cmp.Diff(want, got.Runs[0]); diff != "" {
+		t.Errorf("run mismatch (-want +got):\n%v", diff)
+	}
+	if n := len(l.Runs[0].Tool.Driver.Rules); n != 4 {
+		t.Errorf("original log was modified: got %v rules", n)
+	}
+	if _, ok := l.Runs[0].Tool.Driver.Rules[0].Properties["precision"]; ok {
+		t.Errorf("original rule properties were modified")
+	}
+	if *l.Runs[0].Results[0].RuleIndex != 3 {
+		t.Errorf("original result was modified")
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import "strings"
+
+// LocalizedRule returns the rule of the driver with the provided
+// identifier, with its descriptions translated into the provided
+// [BCP 47] language. The translation is looked up in
+// [Run.Translations] among the translations of the driver. If there
+// is no translation for the language, a translation for its primary
+// language subtag is used, so "fr" is used for "fr-CA". Language tags
+// are compared case-insensitively. Descriptions that are not
+// translated are kept. It returns false if the driver has no rule
+// with the provided identifier.
+//
+// [BCP 47]: https://www.rfc-editor.org/info/bcp47
+func (run Run) LocalizedRule(id, language string) (rule Rule, found bool) {
+	for _, r := range run.Tool.Driver.Rules {
+		if r.ID == id {
+			rule, found = r, true
+			break
+		}
+	}
+	if !found {
+		return Rule{}, false
+	}
+
+	tr, ok := run.findTranslation(language)
+	if !ok {
+		return rule, true
+	}
+	for _, r := range tr.Rules {
+		if r.ID != id {
+			continue
+		}
+		if !r.ShortDescription.isEmpty() {
+			rule.ShortDescription = r.ShortDescription
+		}
+		if !r.FullDescription.isEmpty() {
+			rule.FullDescription = r.FullDescription
+		}
+		if !r.Help.isEmpty() {
+			rule.Help = r.Help
+		}
+		break
+	}
+	return rule, true
+}
+
+// LocalizedGlobalMessageString returns the global message string of
+// the driver with the provided identifier, translated into the
+// provided language. Translations are looked up as described in
+// [Run.LocalizedRule]. It returns false if neither the translation nor
+// the driver have a global message string with that identifier.
+func (run Run) LocalizedGlobalMessageString(id, language string) (s Description, found bool) {
+	if tr, ok := run.findTranslation(language); ok {
+		if s, found := tr.GlobalMessageStrings[id]; found {
+			return s, true
+		}
+	}
+	s, found = run.Tool.Driver.GlobalMessageStrings[id]
+	return s, found
+}
+
+// findTranslation returns the translation of the driver into the
+// provided language. See [Run.LocalizedRule].
+func (run Run) findTranslation(language string) (Driver, bool) {
+	primary, _, _ := strings.Cut(language, "-")
+
+	var fallback *Driver
+	for i, tr := range run.Translations {
+		if tc := tr.AssociatedComponent; tc != nil && tc.Name != "" && tc.Name != run.Tool.Driver.Name {
+			continue
+		}
+		switch {
+		case strings.EqualFold(tr.Language, language):
+			return tr, true
+		case fallback == nil && strings.EqualFold(tr.Language, primary):
+			fallback = &run.Translations[i]
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return Driver{}, false
+}
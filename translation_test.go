@@ -0,0 +1,176 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun_LocalizedRule(t *testing.T) {
+	run := Run{
+		Tool: Tool{
+			Driver: Driver{
+				Name: "linter",
+				Rules: []Rule{
+					{
+						ID:               "R1",
+						ShortDescription: Description{Text: "Unused variable."},
+						FullDescription:  Description{Text: "The variable is never used."},
+						HelpURI:          "https://example.com/R1",
+					},
+				},
+			},
+		},
+		Translations: []Driver{
+			{
+				Name:                "linter",
+				Language:            "fr",
+				AssociatedComponent: &ToolComponentReference{Name: "linter"},
+				Rules: []Rule{
+					{ID: "R1", ShortDescription: Description{Text: "Variable inutilisée."}},
+				},
+			},
+			{
+				Name:     "linter",
+				Language: "fr-CA",
+				Rules: []Rule{
+					{ID: "R1", ShortDescription: Description{Text: "Variable non utilisée."}},
+				},
+			},
+			{
+				Name:                "plugin",
+				Language:            "es",
+				AssociatedComponent: &ToolComponentReference{Name: "plugin"},
+				Rules: []Rule{
+					{ID: "R1", ShortDescription: Description{Text: "Variable no usada."}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		language  string
+		wantShort string
+		wantFound bool
+	}{
+		{
+			name:      "exact language",
+			id:        "R1",
+			language:  "FR-ca",
+			wantShort: "Variable non utilisée.",
+			wantFound: true,
+		},
+		{
+			name:      "primary language",
+			id:        "R1",
+			language:  "fr-BE",
+			wantShort: "Variable inutilisée.",
+			wantFound: true,
+		},
+		{
+			name:      "other component",
+			id:        "R1",
+			language:  "es",
+			wantShort: "Unused variable.",
+			wantFound: true,
+		},
+		{
+			name:      "no translation",
+			id:        "R1",
+			language:  "de",
+			wantShort: "Unused variable.",
+			wantFound: true,
+		},
+		{
+			name:      "unknown rule",
+			id:        "R2",
+			language:  "fr",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, found := run.LocalizedRule(tt.id, tt.language)
+			if found != tt.wantFound {
+				t.Fatalf("found mismatch: want: %v, got: %v", tt.wantFound, found)
+			}
+			if !found {
+				return
+			}
+			if diff := cmp.Diff(tt.wantShort, rule.ShortDescription.Text); diff != "" {
+				t.Errorf("short description mismatch (-want +got):\n%v", diff)
+			}
+			if diff := cmp.Diff("The variable is never used.", rule.FullDescription.Text); diff != "" {
+				t.Errorf("full description mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestRun_LocalizedGlobalMessageString(t *testing.T) {
+	run := Run{
+		Tool: Tool{
+			Driver: Driver{
+				Name: "linter",
+				GlobalMessageStrings: map[string]Description{
+					"hello": {Text: "Hello."},
+					"bye":   {Text: "Bye."},
+				},
+			},
+		},
+		Translations: []Driver{
+			{
+				Language: "es",
+				GlobalMessageStrings: map[string]Description{
+					"hello": {Text: "Hola."},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		language  string
+		want      Description
+		wantFound bool
+	}{
+		{
+			name:      "translated",
+			id:        "hello",
+			language:  "es",
+			want:      Description{Text: "Hola."},
+			wantFound: true,
+		},
+		{
+			name:      "not translated",
+			id:        "bye",
+			language:  "es",
+			want:      Description{Text: "Bye."},
+			wantFound: true,
+		},
+		{
+			name:      "unknown",
+			id:        "other",
+			language:  "es",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := run.LocalizedGlobalMessageString(tt.id, tt.language)
+			if found != tt.wantFound {
+				t.Fatalf("found mismatch: want: %v, got: %v", tt.wantFound, found)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("message string mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// errUnsupportedScheme is returned by [ArtifactLocation.Path] when the
+// artifact URI has a scheme different from "file".
+var errUnsupportedScheme = errors.New("unsupported artifact URI scheme")
+
+// NewArtifactLocation returns an [ArtifactLocation] pointing to the
+// provided OS path. The path is converted into a percent-encoded URI
+// reference as required by the SARIF specification. Absolute paths
+// are converted into "file" URIs.
+func NewArtifactLocation(path, uriBaseID string) ArtifactLocation {
+	p := filepath.ToSlash(path)
+	u := url.URL{Path: p}
+	if filepath.IsAbs(path) {
+		u.Scheme = "file"
+		if !strings.HasPrefix(p, "/") {
+			// Windows paths like "C:/foo" require a leading
+			// slash to be a valid URI path.
+			u.Path = "/" + p
+		}
+	}
+	return ArtifactLocation{
+		URI:       u.String(),
+		URIBaseID: uriBaseID,
+	}
+}
+
+// Path returns the clean OS path represented by the artifact URI. It
+// returns error if the URI is malformed or if it is an absolute URI
+// with a scheme different from "file".
+func (loc ArtifactLocation) Path() (string, error) {
+	if err := loc.Validate(); err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(loc.URI)
+	if err != nil {
+		return "", fmt.Errorf("parse artifact URI: %w", err)
+	}
+	if u.Scheme != "" && u.Scheme != "file" {
+		return "", fmt.Errorf("%w: %v", errUnsupportedScheme, u.Scheme)
+	}
+
+	p := u.Path
+	if u.Scheme == "file" && filepath.Separator == '\\' && len(p) >= 3 && p[0] == '/' && p[2] == ':' {
+		// Remove the leading slash of Windows paths like
+		// "/C:/foo".
+		p = p[1:]
+	}
+	return filepath.Clean(filepath.FromSlash(p)), nil
+}
+
+// Validate reports whether the artifact URI is a valid [RFC 3986] URI
+// reference. That is, it only contains allowed characters and every
+// other character is percent-encoded.
+//
+// [RFC 3986]: https://www.rfc-editor.org/rfc/rfc3986
+func (loc ArtifactLocation) Validate() error {
+	s := loc.URI
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '%':
+			if i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+				return fmt.Errorf("malformed artifact URI: invalid escape sequence: %q", s)
+			}
+			i += 2
+		case !isURIChar(c):
+			return fmt.Errorf("malformed artifact URI: invalid character %q: %q", c, s)
+		}
+	}
+	if _, err := url.Parse(s); err != nil {
+		return fmt.Errorf("malformed artifact URI: %w", err)
+	}
+	return nil
+}
+
+// isURIChar reports whether c is an unreserved or reserved character
+// as defined by RFC 3986.
+func isURIChar(c byte) bool {
+	if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' {
+		return true
+	}
+	return strings.IndexByte("-._~:/?#[]@!$&'()*+,;=", c) >= 0
+}
+
+// isHex reports whether c is a hexadecimal digit.
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// ResolveArtifactLocation returns the artifact location obtained by
+// resolving the URI of loc against the URI its base identifier refers
+// to in [Run.OriginalURIBaseIDs]. Base URIs can themselves be relative
+// to other base identifiers, which are resolved recursively. The
+// returned location has no base identifier, unless the chain ends in
+// a base identifier that is not defined by the run. A location whose
+// URI is absolute is returned without base identifier. It returns
+// error if the URIs are malformed or if the base identifiers form a
+// cycle.
+func (run Run) ResolveArtifactLocation(loc ArtifactLocation) (ArtifactLocation, error) {
+	seen := make(map[string]bool)
+	for loc.URIBaseID != "" {
+		u, err := url.Parse(loc.URI)
+		if err != nil {
+			return ArtifactLocation{}, fmt.Errorf("parse artifact URI: %w", err)
+		}
+		if u.IsAbs() {
+			break
+		}
+
+		if seen[loc.URIBaseID] {
+			return ArtifactLocation{}, fmt.Errorf("cyclic URI base identifier: %v", loc.URIBaseID)
+		}
+		seen[loc.URIBaseID] = true
+
+		base, ok := run.OriginalURIBaseIDs[loc.URIBaseID]
+		if !ok || base.URI == "" {
+			return loc, nil
+		}
+		bu, err := url.Parse(base.URI)
+		if err != nil {
+			return ArtifactLocation{}, fmt.Errorf("parse URI of base identifier %v: %w", loc.URIBaseID, err)
+		}
+		if !strings.HasSuffix(bu.Path, "/") {
+			// Base URIs must end with a slash. Add it so the
+			// last path segment is not replaced on
+			// resolution.
+			bu.Path += "/"
+		}
+
+		if bu.IsAbs() || strings.HasPrefix(bu.Path, "/") {
+			loc.URI = bu.ResolveReference(u).String()
+		} else {
+			// Relative base URIs cannot be resolved with
+			// [url.URL.ResolveReference], which always
+			// returns an absolute path.
+			loc.URI = bu.String() + loc.URI
+		}
+		loc.URIBaseID = base.URIBaseID
+	}
+	loc.URIBaseID = ""
+	return loc, nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2024 Roi Martin
+
+package sarif
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewArtifactLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "relative",
+			path: filepath.FromSlash("dir/file.go"),
+			want: "dir/file.go",
+		},
+		{
+			name: "spaces",
+			path: filepath.FromSlash("my dir/my file.go"),
+			want: "my%20dir/my%20file.go",
+		},
+		{
+			name: "non-ascii",
+			path: filepath.FromSlash("dir/ñ.go"),
+			want: "dir/%C3%B1.go",
+		},
+		{
+			name: "absolute",
+			path: filepath.FromSlash("/dir/a b.go"),
+			want: "file:///dir/a%20b.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := NewArtifactLocation(tt.path, "SRCROOT")
+			if loc.URI != tt.want {
+				t.Errorf("URI mismatch: want: %v, got: %v", tt.want, loc.URI)
+			}
+			if loc.URIBaseID != "SRCROOT" {
+				t.Errorf("URI base ID mismatch: got: %v", loc.URIBaseID)
+			}
+
+			p, err := loc.Path()
+			if err != nil {
+				t.Fatalf("path error: %v", err)
+			}
+			if p != tt.path {
+				t.Errorf("path mismatch: want: %v, got: %v", tt.path, p)
+			}
+		})
+	}
+}
+
+func TestArtifactLocation_Path(t *testing.T) {
+	tests := []struct {
+		name       string
+		uri        string
+		want       string
+		wantNilErr bool
+	}{
+		{
+			name:       "unclean",
+			uri:        "dir/../other/./file.go",
+			want:       filepath.FromSlash("other/file.go"),
+			wantNilErr: true,
+		},
+		{
+			name:       "escaped",
+			uri:        "a%20b/c.go",
+			want:       filepath.FromSlash("a b/c.go"),
+			wantNilErr: true,
+		},
+		{
+			name:       "unsupported scheme",
+			uri:        "https://example.com/file.go",
+			wantNilErr: false,
+		},
+		{
+			name:       "malformed",
+			uri:        "a b.go",
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ArtifactLocation{URI: tt.uri}.Path()
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if p != tt.want {
+				t.Errorf("path mismatch: want: %v, got: %v", tt.want, p)
+			}
+		})
+	}
+}
+
+func TestArtifactLocation_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		uri        string
+		wantNilErr bool
+	}{
+		{
+			name:       "relative",
+			uri:        "dir/file.go",
+			wantNilErr: true,
+		},
+		{
+			name:       "absolute",
+			uri:        "file:///dir/file.go",
+			wantNilErr: true,
+		},
+		{
+			name:       "escaped",
+			uri:        "dir/%C3%B1.go",
+			wantNilErr: true,
+		},
+		{
+			name:       "empty",
+			uri:        "",
+			wantNilErr: true,
+		},
+		{
+			name:       "space",
+			uri:        "dir/a b.go",
+			wantNilErr: false,
+		},
+		{
+			name:       "non-ascii",
+			uri:        "dir/ñ.go",
+			wantNilErr: false,
+		},
+		{
+			name:       "invalid escape",
+			uri:        "dir/%zz.go",
+			wantNilErr: false,
+		},
+		{
+			name:       "truncated escape",
+			uri:        "dir/file%2",
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ArtifactLocation{URI: tt.uri}.Validate()
+			if (err == nil) != tt.wantNilErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRun_ResolveArtifactLocation(t *testing.T) {
+	run := Run{
+		OriginalURIBaseIDs: map[string]ArtifactLocation{
+			"ROOT":    {URI: "file:///home/user/project/"},
+			"SRCROOT": {URI: "src", URIBaseID: "ROOT"},
+			"REL":     {URI: "lib/"},
+			"UNSET":   {},
+			"LOOP1":   {URI: "a/", URIBaseID: "LOOP2"},
+			"LOOP2":   {URI: "b/", URIBaseID: "LOOP1"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		loc        ArtifactLocation
+		want       ArtifactLocation
+		wantNilErr bool
+	}{
+		{
+			name:       "direct",
+			loc:        ArtifactLocation{URI: "go.mod", URIBaseID: "ROOT"},
+			want:       ArtifactLocation{URI: "file:///home/user/project/go.mod"},
+			wantNilErr: true,
+		},
+		{
+			name:       "chained",
+			loc:        ArtifactLocation{URI: "main%20file.go", URIBaseID: "SRCROOT"},
+			want:       ArtifactLocation{URI: "file:///home/user/project/src/main%20file.go"},
+			wantNilErr: true,
+		},
+		{
+			name:       "relative base",
+			loc:        ArtifactLocation{URI: "a.go", URIBaseID: "REL"},
+			want:       ArtifactLocation{URI: "lib/a.go"},
+			wantNilErr: true,
+		},
+		{
+			name:       "undefined base",
+			loc:        ArtifactLocation{URI: "a.go", URIBaseID: "UNSET"},
+			want:       ArtifactLocation{URI: "a.go", URIBaseID: "UNSET"},
+			wantNilErr: true,
+		},
+		{
+			name:       "unknown base",
+			loc:        ArtifactLocation{URI: "a.go", URIBaseID: "OTHER"},
+			want:       ArtifactLocation{URI: "a.go", URIBaseID: "OTHER"},
+			wantNilErr: true,
+		},
+		{
+			name:       "absolute URI",
+			loc:        ArtifactLocation{URI: "file:///tmp/a.go", URIBaseID: "ROOT"},
+			want:       ArtifactLocation{URI: "file:///tmp/a.go"},
+			wantNilErr: true,
+		},
+		{
+			name:       "cycle",
+			loc:        ArtifactLocation{URI: "a.go", URIBaseID: "LOOP1"},
+			wantNilErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := run.ResolveArtifactLocation(tt.loc)
+			if err != nil {
+				if tt.wantNilErr {
+					t.Fatalf("expected nil error: got: %v", err)
+				}
+				return
+			}
+
+			if !tt.wantNilErr {
+				t.Fatalf("expected non-nil error")
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("artifact location mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}